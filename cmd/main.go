@@ -9,6 +9,9 @@ import (
 	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
 	"github.com/Tomas-vilte/GoMusicBot/internal/metrics"
 	"github.com/Tomas-vilte/GoMusicBot/internal/music/fetcher"
+	"github.com/Tomas-vilte/GoMusicBot/internal/music/httpaudio"
+	"github.com/Tomas-vilte/GoMusicBot/internal/music/locallibrary"
+	"github.com/Tomas-vilte/GoMusicBot/internal/music/source"
 	"github.com/Tomas-vilte/GoMusicBot/internal/profiler"
 	"github.com/Tomas-vilte/GoMusicBot/internal/services/providers/youtube_provider"
 	"github.com/Tomas-vilte/GoMusicBot/internal/storage/s3_audio"
@@ -16,7 +19,9 @@ import (
 	"go.uber.org/zap"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
+	"time"
 )
 
 var (
@@ -29,10 +34,33 @@ var (
 		Store: config.StoreConfig{
 			Type: "memory",
 		},
-		BucketName: os.Getenv("BUCKET_NAME"),
-		Region:     os.Getenv("REGION"),
-		AccessKey:  os.Getenv("ACCESS_KEY"),
-		SecretKey:  os.Getenv("SECRET_KEY"),
+		BucketName:               os.Getenv("BUCKET_NAME"),
+		Region:                   os.Getenv("REGION"),
+		AccessKey:                os.Getenv("ACCESS_KEY"),
+		SecretKey:                os.Getenv("SECRET_KEY"),
+		PrefetchThreshold:        0.5,
+		HistorySize:              50,
+		IdleTimeout:              5 * time.Minute,
+		AloneGracePeriod:         2 * time.Minute,
+		VoteSkipRatio:            0.5,
+		FairQueue:                false,
+		MaxSongsPerUser:          25,
+		MaxQueuedDurationPerUser: 2 * time.Hour,
+		MaxConsecutiveFailures:   3,
+		QueuePageSize:            10,
+		MaxPlaylistSize:          100,
+		SearchResults:            5,
+		QueueViewTTL:             10 * time.Minute,
+		AutocompleteInterval:     2 * time.Second,
+		LocalLibraryDir:          os.Getenv("LOCAL_LIBRARY_DIR"),
+		LocalLibraryCoverDir:     filepath.Join(os.TempDir(), "butakero-covers"),
+		Permissions: map[string]config.PermissionsConfig{
+			"": {
+				DJRoleID:           os.Getenv("DJ_ROLE_ID"),
+				DJCommands:         config.DefaultDJCommands,
+				RemoveOwnSongsOnly: true,
+			},
+		},
 	}
 )
 
@@ -42,10 +70,20 @@ func main() {
 	if err != nil {
 		panic("Error creando el logger: " + err.Error())
 	}
+	// Con STORE_DIR definido, la lista de reproducción de cada servidor se guarda en disco y sobrevive a los reinicios.
+	if dir := os.Getenv("STORE_DIR"); dir != "" {
+		cfg.Store = config.StoreConfig{Type: "file", File: config.FileStoreConfig{Dir: dir}}
+	}
+	// Con REDIS_ADDR definido, la lista de reproducción y el estado se guardan en Redis y se comparten entre instancias.
+	if addr := os.Getenv("REDIS_ADDR"); addr != "" {
+		cfg.Store = config.StoreConfig{Type: "redis", Redis: config.RedisStoreConfig{Addr: addr, Password: os.Getenv("REDIS_PASSWORD")}}
+	}
 	promRegistry := metrics.NewPrometheusRegistry()
 	commandUsageCounter := metrics.NewCommandUsageCounter()
 	cacheMetrics := metrics.NewCacheMetrics()
+	playerEventCounter := metrics.NewPlayerEventCounter()
 	promRegistry.Register(commandUsageCounter)
+	promRegistry.Register(playerEventCounter)
 	promRegistry.RegisterCacheMetrics(cacheMetrics)
 
 	promHTTPServer := metrics.NewPrometheusHTTPServer(":8080", promRegistry)
@@ -85,20 +123,56 @@ func main() {
 		panic("error al crear s3_audio uploader")
 	}
 
-	youtubeFetcher := fetcher.NewYoutubeFetcher(logger, cacheStorage, youtubeService, audioCache, executorCommand, s3upload)
+	youtubeFetcher := fetcher.NewYoutubeFetcher(logger, cacheStorage, youtubeService, audioCache, executorCommand, s3upload).
+		WithMaxPlaylistSize(cfg.MaxPlaylistSize)
+	// YouTube es la fuente por defecto: resuelve sus URLs y también los nombres de canciones que ninguna otra fuente reconoce.
+	songSources := source.NewRegistry(youtubeFetcher)
+	if cfg.LocalLibraryDir != "" {
+		library := locallibrary.NewLibrary(cfg.LocalLibraryDir, cfg.LocalLibraryCoverDir, executorCommand, logger)
+		// La indexación ejecuta ffprobe por cada archivo, así que corre en segundo plano para no demorar el arranque.
+		go func() {
+			if err := library.Index(ctx); err != nil {
+				logger.Error("Error al indexar la biblioteca local", zap.Error(err))
+			}
+		}()
+		songSources.Register(library)
+	}
+	// Las URLs de audio y de radios se registran al final para que no tapen las URLs de las demás fuentes.
+	songSources.Register(httpaudio.NewSource(httpaudio.NewPublicClient(), logger))
 	responseHandler := discord.NewDiscordResponseHandler(logger)
 	sessionService := discord.NewSessionService(dg)
 	presenceNotifier := observer.NewVoicePresenceNotifier()
 
-	handler := discord.NewInteractionHandler(responseHandler, sessionService, youtubeFetcher, storage, cfg, logger, commandUsageCounter, cacheStorage, audioCache, youtubeService, executorCommand, s3upload, presenceNotifier).WithLogger(logger)
+	handler := discord.NewInteractionHandler(responseHandler, sessionService, youtubeFetcher, storage, cfg, logger, commandUsageCounter, cacheStorage, audioCache, youtubeService, executorCommand, s3upload, presenceNotifier).
+		WithLogger(logger).
+		WithPlayerEventCounter(playerEventCounter).
+		WithSongSources(songSources)
 	commandHandler := discord.NewSlashCommandRouter(cfg.CommandPrefix).
 		PlayHandler(handler.PlaySong).
+		PlayNextHandler(handler.PlayNextSong).
+		SearchHandler(handler.SearchSong).
 		SkipHandler(handler.SkipSong).
 		StopHandler(handler.StopPlaying).
 		ListHandler(handler.ListPlaylist).
 		RemoveHandler(handler.RemoveSong).
 		PlayingNowHandler(handler.GetPlayingSong).
-		AddSongOrPlaylistHandler(handler.AddSongOrPlaylist)
+		PauseHandler(handler.PauseSong).
+		ResumeHandler(handler.ResumeSong).
+		SeekHandler(handler.SeekSong).
+		LoopHandler(handler.SetLoopMode).
+		VolumeHandler(handler.SetVolume).
+		AutoplayHandler(handler.SetAutoplay).
+		HistoryHandler(handler.ListHistory).
+		PreviousHandler(handler.PlayPrevious).
+		ShuffleHandler(handler.ShufflePlaylist).
+		MoveHandler(handler.MoveSong).
+		AddSongOrPlaylistHandler(handler.AddSongOrPlaylist).
+		PlayerControlHandler(handler.PlayerControl).
+		QueuePageHandler(handler.QueuePage).
+		SearchResultHandler(handler.AddSearchResult).
+		PlayAutocompleteHandler(handler.PlayAutocomplete).
+		PlayAttachmentHandler(handler.PlayAttachment).
+		AuthorizeHandler(handler.Authorize)
 
 	handler.RegisterEventHandlers(dg, ctx)
 	dg.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -108,6 +182,13 @@ func main() {
 				h(s, i)
 			}
 
+		case discordgo.InteractionApplicationCommandAutocomplete:
+			if h, ok := commandHandler.GetAutocompleteHandlers()[i.ApplicationCommandData().Name]; ok {
+				h(ctx, s, i)
+			}
+			// Discord pide sugerencias con cada tecla: no hace falta revisar la presencia en cada una.
+			return
+
 		default:
 			if h, ok := commandHandler.GetCommandHandlers()[i.ApplicationCommandData().Name]; ok {
 				h(ctx, s, i)
@@ -1,9 +1,15 @@
 package config
 
 import (
+	"fmt"
 	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot/store"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot/store/file_storage"
 	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot/store/inmemory_storage"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot/store/redis_storage"
 	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/redis/go-redis/v9"
+	"sync"
+	"time"
 )
 
 type Config struct {
@@ -16,21 +22,130 @@ type Config struct {
 	Region        string
 	AccessKey     string
 	SecretKey     string
+	// PrefetchThreshold es la fracción de la canción actual a partir de la cual se precarga la siguiente (0 la desactiva).
+	PrefetchThreshold float64
+	// HistorySize es la cantidad máxima de canciones que se guardan en el historial de cada servidor.
+	HistorySize int
+	// IdleTimeout es el tiempo que el bot permanece en el canal de voz después de que se vacía la lista de reproducción.
+	IdleTimeout time.Duration
+	// AloneGracePeriod es el tiempo que la reproducción queda en pausa cuando todos salen del canal antes de desconectarse.
+	AloneGracePeriod time.Duration
+	// VoteSkipRatio es la fracción de oyentes que debe votar para saltar una canción (0 la desactiva).
+	VoteSkipRatio float64
+	// FairQueue intercala por turnos las canciones de cada solicitante en la lista de reproducción.
+	FairQueue bool
+	// MaxSongsPerUser es la cantidad máxima de canciones en cola por usuario (0 sin límite).
+	MaxSongsPerUser int
+	// MaxQueuedDurationPerUser es la duración total máxima de las canciones en cola por usuario (0 sin límite).
+	MaxQueuedDurationPerUser time.Duration
+	// SearchResults es la cantidad de resultados que muestra el comando de búsqueda.
+	SearchResults int
+	// MaxPlaylistSize es la cantidad máxima de canciones que se importan de una lista de reproducción de YouTube.
+	MaxPlaylistSize int
+	// QueuePageSize es la cantidad de canciones por página al mostrar la lista de reproducción.
+	QueuePageSize int
+	// QueueViewTTL es el tiempo durante el que funcionan los botones de página de la lista de reproducción.
+	QueueViewTTL time.Duration
+	// AutocompleteInterval es el tiempo mínimo entre búsquedas en YouTube del autocompletado de cada servidor.
+	AutocompleteInterval time.Duration
+	// LocalLibraryDir es el directorio de la biblioteca de música local que se busca con "local:" (vacío la desactiva).
+	LocalLibraryDir string
+	// LocalLibraryCoverDir es el directorio donde se guardan las portadas extraídas de la biblioteca local.
+	LocalLibraryCoverDir string
+	// MaxConsecutiveFailures es la cantidad de canciones seguidas que pueden fallar antes de abandonar la lista de reproducción.
+	MaxConsecutiveFailures int
+	// Permissions contiene los permisos de cada servidor indexados por su ID. La clave vacía aplica a los servidores sin configuración propia.
+	Permissions map[string]PermissionsConfig
 }
 
+// PermissionsConfig define quién puede usar cada subcomando en un servidor.
+type PermissionsConfig struct {
+	// DJRoleID es el rol de DJ. Si está vacío, todos los miembros son DJ.
+	DJRoleID string
+	// DJCommands son los subcomandos reservados para los DJ.
+	DJCommands []string
+	// CommandRoles son los roles que pueden usar cada subcomando, además de los DJ.
+	CommandRoles map[string][]string
+	// RemoveOwnSongsOnly indica que quien no es DJ solo puede eliminar las canciones que agregó.
+	RemoveOwnSongsOnly bool
+}
+
+// DefaultDJCommands son los subcomandos que afectan a todos los oyentes y se reservan para los DJ por defecto.
+var DefaultDJCommands = []string{"stop", "playnext", "seek", "loop", "volume", "autoplay", "previous", "shuffle", "move"}
+
 type StoreConfig struct {
-	Type string
-	File FileStoreConfig
+	Type  string
+	File  FileStoreConfig
+	Redis RedisStoreConfig
 }
 
 type FileStoreConfig struct {
 	Dir string
 }
 
-func GetPlaylistStore(cfg *Config, guildID string, logger logging.Logger) (store.SongStorage, store.StateStorage) {
+// RedisStoreConfig configura la conexión a Redis, compartida por todos los servidores.
+type RedisStoreConfig struct {
+	Addr      string
+	Password  string
+	DB        int
+	KeyPrefix string // Prefijo de las claves de cada servidor. Por defecto "butakero".
+}
+
+var (
+	redisClientOnce sync.Once
+	redisClient     redis.UniversalClient
+)
+
+// getRedisClient devuelve el cliente de Redis, creándolo la primera vez que se usa.
+func getRedisClient(cfg RedisStoreConfig) redis.UniversalClient {
+	redisClientOnce.Do(func() {
+		redisClient = redis.NewClient(&redis.Options{
+			Addr:     cfg.Addr,
+			Password: cfg.Password,
+			DB:       cfg.DB,
+		})
+	})
+	return redisClient
+}
+
+// GetPlaylistStore devuelve la lista de reproducción y el estado del reproductor del servidor según el tipo de store
+// configurado. Devuelve un error si no se pudo abrir el store, por ejemplo si no se puede escribir en su directorio.
+func GetPlaylistStore(cfg *Config, guildID string, logger logging.Logger) (store.SongStorage, store.StateStorage, error) {
 	switch cfg.Store.Type {
 	case "memory":
-		return inmemory_storage.NewInmemorySongStorage(logger), inmemory_storage.NewInmemoryStateStorage(logger)
+		return inmemory_storage.NewInmemorySongStorage(logger).WithFairQueue(cfg.FairQueue), inmemory_storage.NewInmemoryStateStorage(logger), nil
+	case "file":
+		songStorage, stateStorage, err := file_storage.NewFileStorage(cfg.Store.File.Dir, guildID, logger)
+		if err != nil {
+			return nil, nil, fmt.Errorf("no se pudo abrir el store de archivos: %w", err)
+		}
+		return songStorage.WithFairQueue(cfg.FairQueue), stateStorage, nil
+	case "redis":
+		client := getRedisClient(cfg.Store.Redis)
+		keyPrefix := cfg.Store.Redis.KeyPrefix
+		if keyPrefix == "" {
+			keyPrefix = "butakero"
+		}
+		return redis_storage.NewRedisSongStorage(client, keyPrefix, guildID, logger).WithFairQueue(cfg.FairQueue),
+			redis_storage.NewRedisStateStorage(client, keyPrefix, guildID, logger), nil
+	default:
+		return nil, nil, fmt.Errorf("tipo de store inválido: %q", cfg.Store.Type)
+	}
+}
+
+// GetGuildPermissions devuelve los permisos del servidor, o los permisos por defecto si no tiene configuración propia.
+func GetGuildPermissions(cfg *Config, guildID string) PermissionsConfig {
+	if permissions, ok := cfg.Permissions[guildID]; ok {
+		return permissions
+	}
+	return cfg.Permissions[""]
+}
+
+func GetHistoryStore(cfg *Config, guildID string, logger logging.Logger) store.HistoryStorage {
+	switch cfg.Store.Type {
+	// El historial solo se usa para volver a pedir canciones, por lo que no se guarda entre reinicios.
+	case "memory", "file", "redis":
+		return inmemory_storage.NewInmemoryHistoryStorage(logger, cfg.HistorySize)
 	default:
 		panic("tipo de store invalido")
 	}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetPlaylistStore_FileStoreError(t *testing.T) {
+	// El directorio del store no se puede crear porque su padre es un archivo.
+	parent := filepath.Join(t.TempDir(), "archivo")
+	assert.NoError(t, os.WriteFile(parent, nil, 0o644))
+	cfg := &Config{Store: StoreConfig{Type: "file", File: FileStoreConfig{Dir: filepath.Join(parent, "store")}}}
+	logger := new(logging.MockLogger)
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+
+	songStorage, stateStorage, err := GetPlaylistStore(cfg, "guild", logger)
+
+	assert.Error(t, err)
+	assert.Nil(t, songStorage)
+	assert.Nil(t, stateStorage)
+}
+
+func TestGetPlaylistStore_InvalidType(t *testing.T) {
+	_, _, err := GetPlaylistStore(&Config{Store: StoreConfig{Type: "papel"}}, "guild", new(logging.MockLogger))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+	"path"
+	"strings"
+)
+
+// PlayAttachmentCommand es el nombre del comando del menú contextual de los mensajes que reproduce sus archivos de audio.
+const PlayAttachmentCommand = "Play with Butakero"
+
+// attachmentAudioExtensions son las extensiones de los archivos adjuntos que se aceptan si Discord no informa su tipo.
+var attachmentAudioExtensions = map[string]struct{}{
+	".mp3": {}, ".ogg": {}, ".oga": {}, ".opus": {}, ".m4a": {}, ".aac": {}, ".flac": {}, ".wav": {},
+}
+
+// songRequest es lo que se pidió reproducir: un texto que se busca en las fuentes de canciones o archivos adjuntos.
+type songRequest struct {
+	input       string
+	attachments []*discordgo.MessageAttachment
+	// uploader es quien subió los archivos adjuntos, que se muestra junto a quien pidió las canciones.
+	uploader string
+}
+
+// name devuelve el texto con el que se muestra el pedido mientras se busca.
+func (r songRequest) name() string {
+	if len(r.attachments) == 0 {
+		return r.input
+	}
+	names := make([]string, 0, len(r.attachments))
+	for _, attachment := range r.attachments {
+		names = append(names, attachment.Filename)
+	}
+	return strings.Join(names, ", ")
+}
+
+// assignRequester devuelve copias de las canciones pedidas por el miembro, para no modificar canciones compartidas
+// con la caché de búsquedas. Si los archivos adjuntos los subió otra persona, se guarda aparte quién los subió.
+func (r songRequest) assignRequester(songs []*voice.Song, member *discordgo.Member) []*voice.Song {
+	memberName := getMemberName(member)
+	requested := make([]*voice.Song, 0, len(songs))
+	for _, song := range songs {
+		song := *song
+		song.RequestedBy = &memberName
+		song.RequesterID = member.User.ID
+		if r.uploader != memberName {
+			song.UploadedBy = r.uploader
+		}
+		requested = append(requested, &song)
+	}
+	return requested
+}
+
+// isAudioAttachment indica si el archivo adjunto es de audio, según el tipo que informa Discord o su extensión.
+func isAudioAttachment(attachment *discordgo.MessageAttachment) bool {
+	if strings.HasPrefix(attachment.ContentType, "audio/") || attachment.ContentType == "application/ogg" {
+		return true
+	}
+	_, ok := attachmentAudioExtensions[strings.ToLower(path.Ext(attachment.Filename))]
+	return ok
+}
+
+// audioAttachments devuelve los archivos adjuntos de audio.
+func audioAttachments(attachments []*discordgo.MessageAttachment) []*discordgo.MessageAttachment {
+	var audio []*discordgo.MessageAttachment
+	for _, attachment := range attachments {
+		if attachment != nil && isAudioAttachment(attachment) {
+			audio = append(audio, attachment)
+		}
+	}
+	return audio
+}
+
+// getUserName devuelve el nombre visible del usuario.
+func getUserName(user *discordgo.User) string {
+	if user.GlobalName != "" {
+		return user.GlobalName
+	}
+	return user.Username
+}
+
+// PlayAttachment maneja el comando del menú contextual de los mensajes: agrega a la lista de reproducción los archivos
+// de audio del mensaje, con el nombre del archivo como título. Las canciones quedan pedidas por quien usa el comando, y
+// el autor del mensaje se muestra aparte como quien subió los archivos. Se aplican los mismos permisos que al usar "play".
+func (handler *InteractionHandler) PlayAttachment(ctx context.Context, s *discordgo.Session, ic *discordgo.InteractionCreate) {
+	if !handler.Authorize(s, ic, &discordgo.ApplicationCommandInteractionDataOption{Name: "play"}) {
+		return
+	}
+
+	data := ic.ApplicationCommandData()
+	var message *discordgo.Message
+	if data.Resolved != nil {
+		message = data.Resolved.Messages[data.TargetID]
+	}
+	var attachments []*discordgo.MessageAttachment
+	if message != nil {
+		attachments = audioAttachments(message.Attachments)
+	}
+	if len(attachments) == 0 {
+		handler.respondNoAudioAttachment(ic)
+		return
+	}
+
+	request := songRequest{attachments: attachments}
+	if message.Author != nil {
+		request.uploader = getUserName(message.Author)
+	}
+	handler.queueSongs(ctx, s, ic, request, false)
+}
+
+// resolveRequest busca las canciones del pedido. Los archivos adjuntos se reproducen por su URL, con el nombre
+// del archivo como título. Esa URL está firmada y vence; si venció al reproducirse, la fuente devuelve
+// httpaudio.ErrAttachmentExpired y el reproductor lo informa como cualquier canción fallida.
+func (handler *InteractionHandler) resolveRequest(ctx context.Context, request songRequest) ([]*voice.Song, error) {
+	if len(request.attachments) == 0 {
+		return handler.sources.Resolve(ctx, request.input)
+	}
+
+	songs := make([]*voice.Song, 0, len(request.attachments))
+	for _, attachment := range request.attachments {
+		resolved, err := handler.sources.Resolve(ctx, attachment.URL)
+		if err != nil {
+			return nil, fmt.Errorf("error al buscar el archivo %s: %w", attachment.Filename, err)
+		}
+		for _, song := range resolved {
+			song := *song
+			song.Title = attachment.Filename
+			songs = append(songs, &song)
+		}
+	}
+	return songs, nil
+}
+
+// respondNoAudioAttachment avisa que no hay archivos de audio para reproducir.
+func (handler *InteractionHandler) respondNoAudioAttachment(ic *discordgo.InteractionCreate) {
+	if err := handler.responseHandler.RespondWithEphemeralMessage(handler.session, ic.Interaction, ErrorMessageNoAudioAttachment); err != nil {
+		handler.logger.Error("falló al responder que no hay archivos de audio", zap.Error(err))
+	}
+}
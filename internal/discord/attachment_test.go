@@ -0,0 +1,90 @@
+package discord
+
+import (
+	"context"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/music/source"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+// urlSource es una fuente que devuelve una canción con el nombre del archivo de la URL como título.
+type urlSource struct{}
+
+func (urlSource) Name() string { return "http_audio" }
+
+func (urlSource) CanHandle(string) bool { return true }
+
+func (urlSource) Resolve(_ context.Context, input string) ([]*voice.Song, error) {
+	return []*voice.Song{{Type: "http_audio", Title: input[strings.LastIndex(input, "/")+1:], URL: input, Playable: true}}, nil
+}
+
+func (urlSource) GetDCAData(context.Context, *voice.Song) (io.Reader, error) {
+	return strings.NewReader(""), nil
+}
+
+func TestAudioAttachments(t *testing.T) {
+	attachments := []*discordgo.MessageAttachment{
+		{Filename: "tema.mp3", ContentType: "audio/mpeg"},
+		{Filename: "foto.png", ContentType: "image/png"},
+		{Filename: "nota_de_voz.ogg", ContentType: "audio/ogg"},
+		{Filename: "demo.FLAC"},
+		nil,
+	}
+
+	audio := audioAttachments(attachments)
+
+	assert.Len(t, audio, 3)
+	assert.Equal(t, "tema.mp3", audio[0].Filename)
+	assert.Equal(t, "nota_de_voz.ogg", audio[1].Filename)
+	assert.Equal(t, "demo.FLAC", audio[2].Filename)
+}
+
+func TestSongRequest_Name(t *testing.T) {
+	assert.Equal(t, "la bersuit", songRequest{input: "la bersuit"}.name())
+	assert.Equal(t, "uno.mp3, dos.mp3", songRequest{attachments: []*discordgo.MessageAttachment{
+		{Filename: "uno.mp3"},
+		{Filename: "dos.mp3"},
+	}}.name())
+}
+
+func TestInteractionHandler_ResolveRequest(t *testing.T) {
+	handler := &InteractionHandler{sources: source.NewRegistry(urlSource{})}
+
+	songs, err := handler.resolveRequest(context.Background(), songRequest{attachments: []*discordgo.MessageAttachment{
+		{Filename: "Mi tema (demo).mp3", URL: "https://cdn.discordapp.com/attachments/1/2/Mi_tema_demo.mp3"},
+	}})
+
+	assert.NoError(t, err)
+	assert.Len(t, songs, 1)
+	assert.Equal(t, "Mi tema (demo).mp3", songs[0].Title)
+	assert.Equal(t, "https://cdn.discordapp.com/attachments/1/2/Mi_tema_demo.mp3", songs[0].URL)
+}
+
+func TestSongRequest_AssignRequester(t *testing.T) {
+	member := &discordgo.Member{Nick: "Fito", User: &discordgo.User{ID: "invoker", Username: "fpaez"}}
+	songs := []*voice.Song{{Title: "demo.mp3"}}
+
+	requested := songRequest{uploader: "Charly"}.assignRequester(songs, member)
+
+	assert.Len(t, requested, 1)
+	assert.Equal(t, "Fito", *requested[0].RequestedBy)
+	assert.Equal(t, "invoker", requested[0].RequesterID)
+	assert.Equal(t, "Charly", requested[0].UploadedBy)
+	assert.Nil(t, songs[0].RequestedBy)
+
+	// Si quien pide los archivos es quien los subió, no se repite su nombre.
+	requested = songRequest{uploader: "Fito"}.assignRequester(songs, member)
+	assert.Empty(t, requested[0].UploadedBy)
+
+	requested = songRequest{input: "la bersuit"}.assignRequester(songs, member)
+	assert.Empty(t, requested[0].UploadedBy)
+}
+
+func TestGetUserName(t *testing.T) {
+	assert.Equal(t, "Gustavo", getUserName(&discordgo.User{Username: "gcerati", GlobalName: "Gustavo"}))
+	assert.Equal(t, "gcerati", getUserName(&discordgo.User{Username: "gcerati"}))
+}
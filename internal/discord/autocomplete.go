@@ -0,0 +1,168 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxAutocompleteChoices es la cantidad máxima de sugerencias que admite Discord.
+	maxAutocompleteChoices = 25
+	// maxAutocompleteHistory es la cantidad máxima de sugerencias que salen del historial.
+	maxAutocompleteHistory = 10
+	// maxAutocompletePlaylist es la cantidad máxima de sugerencias que salen de la lista de reproducción.
+	maxAutocompletePlaylist = 5
+	// autocompleteSearchResults es la cantidad de resultados de YouTube que se piden por búsqueda.
+	autocompleteSearchResults = 5
+	// minAutocompleteSearchLength es la longitud mínima del texto para buscar en YouTube.
+	minAutocompleteSearchLength = 3
+	// autocompleteSearchTimeout deja margen para responder antes de los 3 segundos que espera Discord.
+	autocompleteSearchTimeout = 2 * time.Second
+	// defaultAutocompleteSearchInterval es el tiempo mínimo entre búsquedas en YouTube de un servidor si no se configuró otro.
+	defaultAutocompleteSearchInterval = 2 * time.Second
+	// autocompleteCacheKeyPrefix separa las búsquedas del autocompletado de las demás entradas de la caché.
+	autocompleteCacheKeyPrefix = "autocomplete:"
+)
+
+// autocompleteLimiter limita las búsquedas en YouTube del autocompletado a una cada interval por servidor,
+// porque Discord pide sugerencias con cada tecla y cada búsqueda consume cuota de la API.
+type autocompleteLimiter struct {
+	mu       sync.Mutex
+	last     map[string]time.Time
+	interval time.Duration
+	now      func() time.Time
+}
+
+// newAutocompleteLimiter crea un limitador que permite una búsqueda cada interval por servidor.
+func newAutocompleteLimiter(interval time.Duration) *autocompleteLimiter {
+	if interval <= 0 {
+		interval = defaultAutocompleteSearchInterval
+	}
+	return &autocompleteLimiter{
+		last:     make(map[string]time.Time),
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// allow indica si el servidor puede buscar ahora y, si puede, registra la búsqueda.
+func (l *autocompleteLimiter) allow(guildID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if last, ok := l.last[guildID]; ok && now.Sub(last) < l.interval {
+		return false
+	}
+	l.last[guildID] = now
+	return true
+}
+
+// PlayAutocomplete sugiere canciones para la opción input de los comandos de reproducción: primero las del historial
+// del servidor que coinciden con el texto, después las de la lista de reproducción y por último resultados de YouTube,
+// guardados en la caché de búsquedas. El bot no guarda playlists con nombre, así que la lista de reproducción actual
+// del servidor es la que cumple ese papel.
+func (handler *InteractionHandler) PlayAutocomplete(ctx context.Context, s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) {
+	var query string
+	for _, o := range opt.Options {
+		if o.Focused {
+			query = strings.TrimSpace(o.StringValue())
+		}
+	}
+
+	var history, playlist []*voice.Song
+	player, err := handler.getGuildPlayer(GuildID(ic.GuildID), s)
+	if err != nil {
+		handler.logger.Error("falló al obtener el reproductor para el autocompletado", zap.Error(err))
+	} else {
+		if history, err = player.GetHistory(); err != nil {
+			handler.logger.Error("falló al obtener el historial para el autocompletado", zap.Error(err))
+		}
+		if playlist, err = player.GetPlaylist(); err != nil {
+			handler.logger.Error("falló al obtener la lista de reproducción para el autocompletado", zap.Error(err))
+		}
+	}
+	choices := GenerateAutocompleteChoices(query, history, playlist, handler.autocompleteSearch(ctx, ic.GuildID, query))
+
+	if err := handler.responseHandler.Respond(handler.session, ic.Interaction, discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	}); err != nil {
+		handler.logger.Error("falló al responder con las sugerencias", zap.Error(err))
+	}
+}
+
+// autocompleteSearch devuelve los resultados de YouTube para el texto, desde la caché de búsquedas si ya se buscó.
+// Si el texto es muy corto o el servidor buscó hace poco, no se busca y solo se usa la caché.
+func (handler *InteractionHandler) autocompleteSearch(ctx context.Context, guildID, query string) []*voice.Song {
+	if len([]rune(query)) < minAutocompleteSearchLength {
+		return nil
+	}
+
+	key := autocompleteCacheKeyPrefix + strings.ToLower(query)
+	if songs := handler.caching.Get(key); songs != nil {
+		return songs
+	}
+	if !handler.autocompleteLimiter.allow(guildID) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, autocompleteSearchTimeout)
+	defer cancel()
+	songs, err := handler.songLookup.SearchSongs(ctx, query, autocompleteSearchResults)
+	if err != nil {
+		handler.logger.Info("falló la búsqueda del autocompletado", zap.Error(err), zap.String("query", query))
+		return nil
+	}
+	handler.caching.Set(key, songs)
+	return songs
+}
+
+// GenerateAutocompleteChoices arma las sugerencias a partir de las canciones del historial y de la lista de
+// reproducción que contienen el texto y de los resultados de búsqueda, sin repetir canciones. El valor de cada
+// sugerencia es la URL de la canción.
+func GenerateAutocompleteChoices(query string, history, playlist, results []*voice.Song) []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, maxAutocompleteChoices)
+	seen := make(map[string]struct{})
+	add := func(prefix string, song *voice.Song) bool {
+		if _, ok := seen[song.URL]; ok || song.URL == "" || len(song.URL) > maxSelectOptionLength {
+			return false
+		}
+		seen[song.URL] = struct{}{}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  truncateSelectOption(fmt.Sprintf("%s %s", prefix, song.GetHumanName())),
+			Value: song.URL,
+		})
+		return true
+	}
+
+	lowerQuery := strings.ToLower(query)
+	addMatching := func(prefix string, songs []*voice.Song, limit int) {
+		added := 0
+		for _, song := range songs {
+			if added == limit {
+				break
+			}
+			if strings.Contains(strings.ToLower(song.GetHumanName()), lowerQuery) && add(prefix, song) {
+				added++
+			}
+		}
+	}
+	addMatching("🕘", history, maxAutocompleteHistory)
+	addMatching("📃", playlist, maxAutocompletePlaylist)
+	for _, song := range results {
+		if len(choices) == maxAutocompleteChoices {
+			break
+		}
+		add("🔎", song)
+	}
+	return choices
+}
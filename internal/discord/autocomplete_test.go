@@ -0,0 +1,68 @@
+package discord
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAutocompleteLimiter_Allow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newAutocompleteLimiter(2 * time.Second)
+	limiter.now = func() time.Time { return now }
+
+	assert.True(t, limiter.allow("guild"))
+	assert.False(t, limiter.allow("guild"))
+	assert.True(t, limiter.allow("otro"))
+
+	now = now.Add(2 * time.Second)
+	assert.True(t, limiter.allow("guild"))
+}
+
+func TestGenerateAutocompleteChoices(t *testing.T) {
+	history := []*voice.Song{
+		{Title: "Muchacha ojos de papel", URL: "https://www.youtube.com/watch?v=muchacha"},
+		{Title: "Rasguña las piedras", URL: "https://www.youtube.com/watch?v=rasguna"},
+	}
+	results := []*voice.Song{
+		{Title: "Muchacha ojos de papel", URL: "https://www.youtube.com/watch?v=muchacha"},
+		{Title: "Muchacha (en vivo)", URL: "https://www.youtube.com/watch?v=envivo"},
+	}
+
+	playlist := []*voice.Song{
+		{Title: "Muchacha ojos de papel", URL: "https://www.youtube.com/watch?v=muchacha"},
+		{Title: "Muchacha (acústico)", URL: "https://www.youtube.com/watch?v=acustico"},
+		{Title: "Laura va", URL: "https://www.youtube.com/watch?v=laura"},
+	}
+
+	choices := GenerateAutocompleteChoices("MUCHACHA", history, playlist, results)
+
+	assert.Len(t, choices, 3)
+	assert.Equal(t, "🕘 Muchacha ojos de papel", choices[0].Name)
+	assert.Equal(t, "https://www.youtube.com/watch?v=muchacha", choices[0].Value)
+	assert.Equal(t, "📃 Muchacha (acústico)", choices[1].Name)
+	assert.Equal(t, "🔎 Muchacha (en vivo)", choices[2].Name)
+}
+
+func TestGenerateAutocompleteChoices_PlaylistLimit(t *testing.T) {
+	var playlist []*voice.Song
+	for i := 0; i < 10; i++ {
+		playlist = append(playlist, &voice.Song{Title: "Tema", URL: "https://www.youtube.com/watch?v=" + string(rune('a'+i))})
+	}
+
+	choices := GenerateAutocompleteChoices("", nil, playlist, nil)
+
+	assert.Len(t, choices, maxAutocompletePlaylist)
+}
+
+func TestGenerateAutocompleteChoices_Limit(t *testing.T) {
+	var results []*voice.Song
+	for i := 0; i < 30; i++ {
+		results = append(results, &voice.Song{Title: "Tema", URL: "https://www.youtube.com/watch?v=" + string(rune('a'+i))})
+	}
+
+	choices := GenerateAutocompleteChoices("", nil, nil, results)
+
+	assert.Len(t, choices, maxAutocompleteChoices)
+}
@@ -0,0 +1,126 @@
+package bot
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+// PlayerEvent es un evento del ciclo de vida de las canciones de un reproductor.
+// Los suscriptores distinguen cada evento por su tipo concreto.
+type PlayerEvent interface {
+	// EventName devuelve un nombre corto del evento, útil para métricas y registros.
+	EventName() string
+}
+
+// FinishReason indica por qué terminó una canción.
+type FinishReason string
+
+const (
+	FinishReasonEnded   FinishReason = "ended"   // La canción terminó normalmente.
+	FinishReasonSkipped FinishReason = "skipped" // La canción fue saltada.
+	FinishReasonStopped FinishReason = "stopped" // La reproducción fue detenida.
+	FinishReasonSeeked  FinishReason = "seeked"  // La canción se reinició en otra posición.
+)
+
+type (
+	// SongStartedEvent se publica cuando empieza a sonar una canción.
+	SongStartedEvent struct {
+		Song          *voice.Song
+		TextChannelID string
+	}
+
+	// SongProgressEvent se publica periódicamente mientras suena una canción.
+	SongProgressEvent struct {
+		Song     *voice.Song
+		Position time.Duration
+	}
+
+	// SongFinishedEvent se publica cuando una canción deja de sonar.
+	SongFinishedEvent struct {
+		Song   *voice.Song
+		Reason FinishReason
+	}
+
+	// SongFailedEvent se publica cuando una canción no se pudo reproducir.
+	SongFailedEvent struct {
+		Song *voice.Song
+		Err  error
+	}
+
+	// QueueEmptiedEvent se publica cuando la lista de reproducción se queda sin canciones.
+	QueueEmptiedEvent struct{}
+)
+
+func (SongStartedEvent) EventName() string  { return "song_started" }
+func (SongProgressEvent) EventName() string { return "song_progress" }
+func (SongFinishedEvent) EventName() string { return "song_finished" }
+func (SongFailedEvent) EventName() string   { return "song_failed" }
+func (QueueEmptiedEvent) EventName() string { return "queue_emptied" }
+
+// finishReason traduce el motivo de interrupción de la canción al motivo publicado en los eventos.
+func (i songInterruption) finishReason() FinishReason {
+	switch i {
+	case interruptionSkip:
+		return FinishReasonSkipped
+	case interruptionStop:
+		return FinishReasonStopped
+	case interruptionSeek:
+		return FinishReasonSeeked
+	default:
+		return FinishReasonEnded
+	}
+}
+
+// EventBus distribuye los eventos de un reproductor entre sus suscriptores.
+// Publicar nunca bloquea la reproducción: si un suscriptor no consume a tiempo, pierde el evento.
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers map[chan PlayerEvent]struct{}
+	logger      logging.Logger
+}
+
+// NewEventBus crea un nuevo EventBus sin suscriptores.
+func NewEventBus(logger logging.Logger) *EventBus {
+	return &EventBus{
+		subscribers: make(map[chan PlayerEvent]struct{}),
+		logger:      logger,
+	}
+}
+
+// Subscribe registra un suscriptor con un búfer de bufferSize eventos. Devuelve el canal por el que recibe
+// los eventos y una función para cancelar la suscripción, que cierra el canal.
+func (b *EventBus) Subscribe(bufferSize int) (<-chan PlayerEvent, func()) {
+	ch := make(chan PlayerEvent, bufferSize)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			close(ch)
+			b.mu.Unlock()
+		})
+	}
+	return ch, unsubscribe
+}
+
+// Publish envía el evento a todos los suscriptores.
+func (b *EventBus) Publish(event PlayerEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			b.logger.Debug("Evento descartado porque el suscriptor está ocupado", zap.String("evento", event.EventName()))
+		}
+	}
+}
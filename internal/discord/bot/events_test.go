@@ -0,0 +1,59 @@
+package bot
+
+import (
+	"errors"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestEventBus_PublishToAllSubscribers(t *testing.T) {
+	bus := NewEventBus(new(logging.MockLogger))
+	first, _ := bus.Subscribe(1)
+	second, _ := bus.Subscribe(1)
+
+	song := &voice.Song{Title: "Canción de prueba"}
+	bus.Publish(SongStartedEvent{Song: song})
+
+	for _, ch := range []<-chan PlayerEvent{first, second} {
+		event := <-ch
+		started, ok := event.(SongStartedEvent)
+		assert.True(t, ok)
+		assert.Equal(t, song, started.Song)
+	}
+}
+
+func TestEventBus_DropsWhenSubscriberIsFull(t *testing.T) {
+	logger := new(logging.MockLogger)
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+	bus := NewEventBus(logger)
+	events, _ := bus.Subscribe(1)
+
+	bus.Publish(QueueEmptiedEvent{})
+	bus.Publish(SongFailedEvent{Err: errors.New("error")})
+
+	assert.Equal(t, "queue_emptied", (<-events).EventName())
+	assert.Len(t, events, 0)
+	logger.AssertCalled(t, "Debug", "Evento descartado porque el suscriptor está ocupado", mock.Anything)
+}
+
+func TestEventBus_Unsubscribe(t *testing.T) {
+	bus := NewEventBus(new(logging.MockLogger))
+	events, unsubscribe := bus.Subscribe(1)
+
+	unsubscribe()
+	unsubscribe()
+	bus.Publish(QueueEmptiedEvent{})
+
+	_, open := <-events
+	assert.False(t, open)
+}
+
+func TestSongInterruption_FinishReason(t *testing.T) {
+	assert.Equal(t, FinishReasonEnded, interruptionNone.finishReason())
+	assert.Equal(t, FinishReasonSkipped, interruptionSkip.finishReason())
+	assert.Equal(t, FinishReasonStopped, interruptionStop.finishReason())
+	assert.Equal(t, FinishReasonSeeked, interruptionSeek.finishReason())
+}
@@ -0,0 +1,13 @@
+package bot
+
+// HandleAlone expone handleAlone a las pruebas del paquete bot_test.
+func (p *GuildPlayer) HandleAlone(alone bool) {
+	p.handleAlone(alone)
+}
+
+// SetListeners reemplaza los oyentes del canal de voz del bot por los usuarios indicados.
+func (p *GuildPlayer) SetListeners(userIDs ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	setListeners(p, userIDs...)
+}
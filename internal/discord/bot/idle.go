@@ -0,0 +1,127 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"time"
+)
+
+// IdlePolicy define cuánto tiempo permanece conectado el reproductor cuando no hay actividad.
+type IdlePolicy struct {
+	QueueEmptyTimeout time.Duration // Tiempo que se permanece en el canal de voz después de que se vacía la lista de reproducción.
+	AloneGracePeriod  time.Duration // Tiempo que se mantiene la reproducción en pausa cuando todos salen del canal antes de desconectarse.
+}
+
+// WithIdlePolicy establece la política de inactividad del reproductor.
+func (p *GuildPlayer) WithIdlePolicy(policy IdlePolicy) *GuildPlayer {
+	p.idlePolicy = policy
+	return p
+}
+
+// waitForSongs espera hasta QueueEmptyTimeout a que se agreguen canciones a la lista de reproducción.
+// Mientras tanto el reproductor sigue conectado al canal de voz actual. Retorna true si hay canciones para reproducir.
+func (p *GuildPlayer) waitForSongs(ctx context.Context) bool {
+	if p.idlePolicy.QueueEmptyTimeout <= 0 {
+		return false
+	}
+
+	idleCtx, cancel := context.WithTimeout(ctx, p.idlePolicy.QueueEmptyTimeout)
+	defer cancel()
+	p.mu.Lock()
+	p.idleCancel = cancel
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		p.idleCancel = nil
+		p.mu.Unlock()
+	}()
+
+	p.logger.Info("Esperando nuevas canciones antes de salir del canal de voz", zap.Duration("tiempo", p.idlePolicy.QueueEmptyTimeout))
+	for {
+		select {
+		case <-idleCtx.Done():
+			p.logger.Info("Tiempo de inactividad agotado")
+			return false
+		case trigger := <-p.triggerCh:
+			if trigger.Command != "play" {
+				continue
+			}
+			if trigger.TextChannelID != nil {
+				if err := p.stateStorage.SetTextChannel(*trigger.TextChannelID); err != nil {
+					p.logger.Error("Error al establecer el canal de texto", zap.Error(err))
+				}
+			}
+			songs, err := p.songStorage.GetSongs()
+			if err != nil {
+				p.logger.Error("Error al obtener canciones", zap.Error(err))
+				continue
+			}
+			if len(songs) > 0 {
+				return true
+			}
+		}
+	}
+}
+
+// handleAlone aplica la política de inactividad según si el bot quedó solo en el canal de voz.
+// Al quedar solo pausa la reproducción y, si nadie vuelve dentro del período de gracia, sale del canal de voz.
+func (p *GuildPlayer) handleAlone(alone bool) {
+	p.presenceMu.Lock()
+	defer p.presenceMu.Unlock()
+
+	if alone {
+		if p.aloneTimer != nil {
+			return
+		}
+		if p.idlePolicy.AloneGracePeriod <= 0 {
+			p.leave()
+			return
+		}
+
+		p.autoPaused = p.Pause() == nil
+		p.logger.Info("Todos salieron del canal de voz, esperando su regreso", zap.Duration("tiempo", p.idlePolicy.AloneGracePeriod))
+		p.aloneTimer = time.AfterFunc(p.idlePolicy.AloneGracePeriod, p.leaveAfterGracePeriod)
+		return
+	}
+
+	if p.aloneTimer == nil {
+		return
+	}
+	p.aloneTimer.Stop()
+	p.aloneTimer = nil
+	p.logger.Info("Un miembro volvió al canal de voz")
+
+	if p.autoPaused {
+		p.autoPaused = false
+		if err := p.Resume(); err != nil && !errors.Is(err, ErrNotPaused) && !errors.Is(err, ErrNotPlaying) {
+			p.logger.Error("falló al reanudar la reproducción", zap.Error(err))
+		}
+	}
+}
+
+// leaveAfterGracePeriod sale del canal de voz si nadie volvió durante el período de gracia.
+func (p *GuildPlayer) leaveAfterGracePeriod() {
+	p.presenceMu.Lock()
+	if p.aloneTimer == nil {
+		p.presenceMu.Unlock()
+		return
+	}
+	p.aloneTimer = nil
+	p.autoPaused = false
+	p.presenceMu.Unlock()
+
+	p.leave()
+}
+
+// leave detiene la reproducción y sale del canal de voz. A diferencia de Stop, el reproductor no se queda
+// esperando nuevas canciones durante QueueEmptyTimeout.
+func (p *GuildPlayer) leave() {
+	p.logger.Warn("Desconectando bot debido a la falta de presencia")
+	p.mu.Lock()
+	p.leaveRequested = true
+	p.mu.Unlock()
+	if err := p.Stop(); err != nil {
+		p.logger.Error("falló al detener la reproducción", zap.Error(err))
+	}
+}
@@ -21,6 +21,36 @@ var (
 	ErrNoSongs = errors.New("canción no disponible")
 	// ErrRemoveInvalidPosition indica que la posición de eliminación de la canción es inválida.
 	ErrRemoveInvalidPosition = errors.New("posición inválida")
+	// ErrNotSongOwner indica que la canción a eliminar no fue agregada por quien la quiere eliminar.
+	ErrNotSongOwner = errors.New("la canción no fue agregada por el usuario")
+	// ErrInvalidPosition indica que la posición para insertar o mover una canción es inválida.
+	ErrInvalidPosition = errors.New("posición inválida")
+	// ErrNotPlaying indica que no hay ninguna canción en reproducción.
+	ErrNotPlaying = errors.New("no hay ninguna canción en reproducción")
+	// ErrAlreadyPaused indica que la reproducción ya está en pausa.
+	ErrAlreadyPaused = errors.New("la reproducción ya está en pausa")
+	// ErrNotPaused indica que la reproducción no está en pausa.
+	ErrNotPaused = errors.New("la reproducción no está en pausa")
+	// ErrInvalidSeekPosition indica que la posición solicitada está fuera de la canción.
+	ErrInvalidSeekPosition = errors.New("posición de búsqueda inválida")
+	// ErrSeekLive indica que no se puede reposicionar una transmisión en vivo.
+	ErrSeekLive = errors.New("no se puede reposicionar una transmisión en vivo")
+	// ErrInvalidLoopMode indica que el modo de repetición no es válido.
+	ErrInvalidLoopMode = errors.New("modo de repetición inválido")
+	// ErrInvalidVolume indica que el volumen está fuera del rango permitido.
+	ErrInvalidVolume = errors.New("volumen fuera de rango")
+	// ErrEmptyHistory indica que no hay canciones en el historial.
+	ErrEmptyHistory = errors.New("el historial está vacío")
+)
+
+// songInterruption indica por qué se canceló la canción actual antes de terminar.
+type songInterruption int
+
+const (
+	interruptionNone songInterruption = iota // La canción terminó normalmente.
+	interruptionSkip                         // La canción fue saltada.
+	interruptionStop                         // La reproducción fue detenida.
+	interruptionSeek                         // La canción se reinició en otra posición.
 )
 
 // Trigger representa un disparador para comandos relacionados con la reproducción de música.
@@ -33,19 +63,39 @@ type Trigger struct {
 // DCADataGetter es una función para obtener datos de audio codificados en DCA para una canción específica.
 type DCADataGetter func(ctx context.Context, song *voice.Song) (io.Reader, error)
 
+// RelatedSongGetter es una función para obtener una canción relacionada con otra, descartando las URLs indicadas.
+type RelatedSongGetter func(ctx context.Context, song *voice.Song, exclude []string) (*voice.Song, error)
+
 // GuildPlayer es el reproductor de música para un servidor específico en Discord.
 type GuildPlayer struct {
-	triggerCh       chan Trigger                       // Canal para recibir disparadores de comandos relacionados con la reproducción de música.
-	session         voice.VoiceChatSession             // Interfaz voice.VoiceChatSession Sesión de chat de voz que define métodos para interactuar con la sesión de voz del bot de Discord.
-	songCtxCancel   context.CancelFunc                 // Función de cancelación del contexto de la canción actual.
-	songStorage     store.SongStorage                  // Interfaz store.SongStorage Almacenamiento de canciones para la lista de reproducción.
-	stateStorage    store.StateStorage                 // Interfaz store.StateStorage Almacenamiento de estado para el reproductor de música.
-	dCADataGetter   DCADataGetter                      // Función para obtener datos de audio codificados en DCA para una canción específica.
-	audioBufferSize int                                // Tamaño del búfer de audio para la transmisión de música.
-	logger          logging.Logger                     // Interfaz logging.Logger Registro de eventos y errores.
-	voiceChannelMap map[string]VoiceChannelInfo        // Mapa que contiene información sobre los canales de voz y su estado.
-	message         discordmessenger.ChatMessageSender // Interfaz para enviar mensajes de chat a Discord.
-	mu              sync.Mutex
+	triggerCh              chan Trigger                       // Canal para recibir disparadores de comandos relacionados con la reproducción de música.
+	session                voice.VoiceChatSession             // Interfaz voice.VoiceChatSession Sesión de chat de voz que define métodos para interactuar con la sesión de voz del bot de Discord.
+	songCtxCancel          context.CancelFunc                 // Función de cancelación del contexto de la canción actual.
+	songStorage            store.SongStorage                  // Interfaz store.SongStorage Almacenamiento de canciones para la lista de reproducción.
+	stateStorage           store.StateStorage                 // Interfaz store.StateStorage Almacenamiento de estado para el reproductor de música.
+	historyStorage         store.HistoryStorage               // Interfaz store.HistoryStorage Historial de canciones reproducidas.
+	dCADataGetter          DCADataGetter                      // Función para obtener datos de audio codificados en DCA para una canción específica.
+	audioBufferSize        int                                // Tamaño del búfer de audio para la transmisión de música.
+	logger                 logging.Logger                     // Interfaz logging.Logger Registro de eventos y errores.
+	voiceChannelMap        map[string]VoiceChannelInfo        // Mapa que contiene información sobre los canales de voz y su estado.
+	message                discordmessenger.ChatMessageSender // Interfaz para enviar mensajes de chat a Discord.
+	playMsgID              string                             // ID del mensaje de reproducción de la canción actual.
+	interruption           songInterruption                   // Motivo por el que se canceló la canción actual.
+	relatedSong            RelatedSongGetter                  // Función para obtener canciones relacionadas para la reproducción automática.
+	prefetcher             *prefetcher                        // Precarga el audio de la próxima canción.
+	idlePolicy             IdlePolicy                         // Política de inactividad del reproductor.
+	idleCancel             context.CancelFunc                 // Cancela la espera de nuevas canciones con la lista de reproducción vacía.
+	leaveRequested         bool                               // Indica que se debe salir del canal de voz sin esperar nuevas canciones.
+	aloneTimer             *time.Timer                        // Temporizador para desconectarse si nadie vuelve al canal de voz.
+	autoPaused             bool                               // Indica si la reproducción se pausó porque todos salieron del canal de voz.
+	requesterLimits        RequesterLimits                    // Límites de canciones en cola por solicitante.
+	events                 *EventBus                          // Distribuye los eventos del ciclo de vida de las canciones.
+	maxConsecutiveFailures int                                // Cantidad de canciones seguidas que pueden fallar antes de abandonar la lista de reproducción.
+	voteSkipRatio          float64                            // Fracción de oyentes que debe votar para saltar una canción.
+	skipVotes              map[string]struct{}                // IDs de los usuarios que votaron para saltar la canción actual.
+	skipVoteMsgID          string                             // ID del mensaje con el recuento de votos de la canción actual.
+	presenceMu             sync.Mutex
+	mu                     sync.Mutex
 }
 
 // VoiceChannelInfo contiene información sobre un canal de voz y su estado.
@@ -62,36 +112,61 @@ type VoiceChannelInfo struct {
 }
 
 // NewGuildPlayer crea una nueva instancia de GuildPlayer con los parámetros proporcionados.
-func NewGuildPlayer(session voice.VoiceChatSession, songStorage store.SongStorage, stateStorage store.StateStorage, dCADataGetter DCADataGetter, message discordmessenger.ChatMessageSender, logger logging.Logger) *GuildPlayer {
+func NewGuildPlayer(session voice.VoiceChatSession, songStorage store.SongStorage, stateStorage store.StateStorage, historyStorage store.HistoryStorage, dCADataGetter DCADataGetter, message discordmessenger.ChatMessageSender, logger logging.Logger) *GuildPlayer {
 	return &GuildPlayer{
-		songStorage:     songStorage,
-		stateStorage:    stateStorage,
-		triggerCh:       make(chan Trigger),
-		session:         session,
-		logger:          logger,
-		dCADataGetter:   dCADataGetter,
-		audioBufferSize: 1024 * 1024, // 1 MiB
-		voiceChannelMap: make(map[string]VoiceChannelInfo),
-		message:         message,
+		songStorage:            songStorage,
+		stateStorage:           stateStorage,
+		historyStorage:         historyStorage,
+		triggerCh:              make(chan Trigger),
+		session:                session,
+		logger:                 logger,
+		dCADataGetter:          dCADataGetter,
+		audioBufferSize:        1024 * 1024, // 1 MiB
+		voiceChannelMap:        make(map[string]VoiceChannelInfo),
+		message:                message,
+		skipVotes:              make(map[string]struct{}),
+		events:                 NewEventBus(logger),
+		maxConsecutiveFailures: defaultMaxConsecutiveFailures,
 	}
 }
 
+// Subscribe suscribe al llamador a los eventos del ciclo de vida de las canciones del reproductor.
+// Devuelve el canal de eventos y una función para cancelar la suscripción.
+func (p *GuildPlayer) Subscribe(bufferSize int) (<-chan PlayerEvent, func()) {
+	return p.events.Subscribe(bufferSize)
+}
+
+// WithRelatedSongGetter establece la función usada por la reproducción automática para obtener canciones relacionadas.
+func (p *GuildPlayer) WithRelatedSongGetter(relatedSong RelatedSongGetter) *GuildPlayer {
+	p.relatedSong = relatedSong
+	return p
+}
+
+// WithPrefetcher establece la función para precargar la próxima canción una vez que la actual
+// supera la fracción threshold de su duración. Un threshold fuera de (0, 1] desactiva la precarga.
+func (p *GuildPlayer) WithPrefetcher(fetch PrefetchFunc, threshold float64) *GuildPlayer {
+	if threshold <= 0 || threshold > 1 {
+		p.prefetcher = nil
+		return p
+	}
+	p.prefetcher = newPrefetcher(fetch, threshold, p.logger)
+	return p
+}
+
 // UpdatePresence actualiza la presencia en el canal de voz y maneja la desconexión si es necesario.
 func (p *GuildPlayer) UpdatePresence(voiceState *discordgo.VoiceStateUpdate) {
 	p.logger.Debug("Actualización de presencia recibida", zap.String("guildID", voiceState.GuildID))
 
+	p.mu.Lock()
 	voiceChannelInfo, ok := p.voiceChannelMap[voiceState.GuildID]
+	p.mu.Unlock()
 	if !ok {
 		p.logger.Info("No se encontró información para el canal de voz", zap.String("guildID", voiceState.GuildID))
 		return
 	}
 	p.logger.Debug("Información del canal de voz", zap.Int("membersCount", len(voiceChannelInfo.Members)))
-	if len(voiceChannelInfo.Members) == 1 && voiceChannelInfo.BotID == voiceChannelInfo.Members[0].User.ID {
-		p.logger.Warn("Desconectando bot debido a la falta de presencia", zap.String("guildID", voiceState.GuildID))
-		if err := p.Stop(); err != nil {
-			p.logger.Error("falló al detener la reproducción", zap.Error(err))
-		}
-	}
+	alone := len(voiceChannelInfo.Members) == 1 && voiceChannelInfo.BotID == voiceChannelInfo.Members[0].User.ID
+	p.handleAlone(alone)
 }
 
 // UpdateVoiceState actualiza el mapa de información sobre los canales de voz.
@@ -170,30 +245,190 @@ func (p *GuildPlayer) Close() error {
 	return p.session.Close()
 }
 
-// updateSongPosition actualiza la posición de la canción actual.
-func (p *GuildPlayer) updateSongPosition(song *voice.Song, position time.Duration, textChannel, playMsgID string) {
-	if err := p.stateStorage.SetCurrentSong(&voice.PlayedSong{Song: *song, Position: position}); err != nil {
+// updateSongPosition guarda la posición de la canción actual y el título que anuncia la transmisión, y los muestra en el mensaje de reproducción.
+func (p *GuildPlayer) updateSongPosition(song *voice.Song, position time.Duration, streamTitle, textChannel, playMsgID string) {
+	if err := p.stateStorage.SetCurrentSong(&voice.PlayedSong{Song: *song, Position: position, StreamTitle: streamTitle}); err != nil {
 		p.logger.Error("Error fallo al establecer la posicion actual de la cancion", zap.Error(err))
 	}
-	if err := p.message.EditPlayMessage(textChannel, playMsgID, &voice.PlayMessage{Song: song, Position: position}); err != nil {
+	message := p.playMessage(song, position)
+	message.StreamTitle = streamTitle
+	if err := p.message.EditPlayMessage(textChannel, playMsgID, message); err != nil {
 		p.logger.Error("Error fallo al editar el mensaje")
 	}
 }
 
+// finishPlayMessage dibuja el mensaje de reproducción por última vez, con los botones deshabilitados.
+func (p *GuildPlayer) finishPlayMessage(song *voice.Song, position time.Duration, textChannel, playMsgID string) {
+	message := p.playMessage(song, position)
+	message.Paused = false
+	message.Finished = true
+	if err := p.message.EditPlayMessage(textChannel, playMsgID, message); err != nil {
+		p.logger.Error("Error fallo al editar el mensaje", zap.Error(err))
+	}
+}
+
+// refreshPlayMessage vuelve a dibujar el mensaje de reproducción con el estado actual del reproductor. Debe llamarse con p.mu tomado.
+func (p *GuildPlayer) refreshPlayMessage(currentSong *voice.PlayedSong) {
+	if p.playMsgID == "" {
+		return
+	}
+	textChannel, err := p.stateStorage.GetTextChannel()
+	if err != nil {
+		p.logger.Error("Error al obtener el canal de texto", zap.Error(err))
+		return
+	}
+	message := p.playMessage(&currentSong.Song, currentSong.Position)
+	message.StreamTitle = currentSong.StreamTitle
+	if err := p.message.EditPlayMessage(textChannel, p.playMsgID, message); err != nil {
+		p.logger.Error("Error fallo al editar el mensaje", zap.Error(err))
+	}
+}
+
+// playMessage arma el mensaje de reproducción de la canción con el estado actual del reproductor,
+// que define qué botones se muestran habilitados.
+func (p *GuildPlayer) playMessage(song *voice.Song, position time.Duration) *voice.PlayMessage {
+	message := &voice.PlayMessage{Song: song, Position: position, LoopMode: voice.LoopModeOff}
+
+	paused, err := p.stateStorage.GetPaused()
+	if err != nil {
+		p.logger.Error("Error al obtener el estado de pausa", zap.Error(err))
+	}
+	message.Paused = paused
+
+	if mode, err := p.stateStorage.GetLoopMode(); err != nil {
+		p.logger.Error("Error al obtener el modo de repetición", zap.Error(err))
+	} else {
+		message.LoopMode = mode
+	}
+
+	if songs, err := p.songStorage.GetSongs(); err != nil {
+		p.logger.Error("Error al obtener la lista de reproducción", zap.Error(err))
+	} else {
+		message.QueueLength = len(songs)
+	}
+	return message
+}
+
+// resetPause limpia el estado de pausa al terminar una canción. Si estaba en pausa también reanuda la sesión de voz:
+// una canción pausada mientras se obtenía su audio nunca llegó a transmitirse, y la sesión seguiría en pausa
+// reteniendo el primer frame de la canción siguiente.
+func (p *GuildPlayer) resetPause() {
+	paused, err := p.stateStorage.GetPaused()
+	if err != nil {
+		p.logger.Error("Error al obtener el estado de pausa", zap.Error(err))
+	}
+	if paused || err != nil {
+		p.session.Resume()
+	}
+	if err := p.stateStorage.SetPaused(false); err != nil {
+		p.logger.Error("Error al limpiar el estado de pausa", zap.Error(err))
+	}
+}
+
+// nextSong devuelve la primera canción de la lista de reproducción, o nil si está vacía.
+func (p *GuildPlayer) nextSong() (*voice.Song, error) {
+	songs, err := p.songStorage.GetSongs()
+	if err != nil {
+		return nil, err
+	}
+	if len(songs) == 0 {
+		return nil, nil
+	}
+	return songs[0], nil
+}
+
+// maybePrefetch precarga la próxima canción si la canción actual superó el punto de precarga.
+func (p *GuildPlayer) maybePrefetch(ctx context.Context, current *voice.Song, position time.Duration) {
+	if p.prefetcher == nil || current.Duration <= 0 || position < time.Duration(p.prefetcher.threshold*float64(current.Duration)) {
+		return
+	}
+
+	next, err := p.nextSong()
+	if err != nil {
+		p.logger.Error("Error al obtener la próxima canción", zap.Error(err))
+		return
+	}
+	// Las canciones que no empiezan desde el principio no se leen de la caché.
+	if next == nil || next.StartPosition > 0 {
+		return
+	}
+
+	p.prefetcher.start(ctx, next)
+}
+
+// refreshPrefetch cancela la precarga en curso si la próxima canción de la lista cambió.
+func (p *GuildPlayer) refreshPrefetch() {
+	if p.prefetcher == nil {
+		return
+	}
+
+	next, err := p.nextSong()
+	if err != nil {
+		p.logger.Error("Error al obtener la próxima canción", zap.Error(err))
+		return
+	}
+	nextURL := ""
+	if next != nil {
+		nextURL = next.URL
+	}
+	p.prefetcher.invalidate(nextURL)
+}
+
 // GetVoiceChannelInfo devuelve el mapa con toda la información de los canales de voz y su estado.
 func (p *GuildPlayer) GetVoiceChannelInfo() map[string]VoiceChannelInfo {
 	return p.voiceChannelMap
 }
 
-// AddSong agrega una o más canciones a la lista de reproducción.
-func (p *GuildPlayer) AddSong(textChannelID, voiceChannelID *string, songs ...*voice.Song) error {
-	for _, song := range songs {
+// AddSong agrega una o más canciones a la lista de reproducción, descartando las que superan los límites del solicitante.
+func (p *GuildPlayer) AddSong(textChannelID, voiceChannelID *string, songs ...*voice.Song) (*QueueResult, error) {
+	p.mu.Lock()
+	result, err := p.admitSongs(songs)
+	if err != nil {
+		p.mu.Unlock()
+		return result, err
+	}
+	for _, song := range result.Added {
 		if err := p.songStorage.AppendSong(song); err != nil {
+			p.mu.Unlock()
 			p.logger.Error("Error al agregar canción a la lista de reproducción", zap.Error(err))
-			return fmt.Errorf("al agregar canción: %w", err)
+			return nil, fmt.Errorf("al agregar canción: %w", err)
+		}
+	}
+	p.mu.Unlock()
+
+	p.refreshPrefetch()
+	p.triggerPlay(textChannelID, voiceChannelID)
+
+	p.logger.Info("Canciones agregadas a la lista de reproducción", zap.Int("cantidad", len(result.Added)), zap.Int("descartadas", result.Rejected))
+	return result, nil
+}
+
+// AddSongNext agrega una o más canciones al principio de la lista de reproducción, respetando su orden.
+func (p *GuildPlayer) AddSongNext(textChannelID, voiceChannelID *string, songs ...*voice.Song) (*QueueResult, error) {
+	p.mu.Lock()
+	result, err := p.admitSongs(songs)
+	if err != nil {
+		p.mu.Unlock()
+		return result, err
+	}
+	for i, song := range result.Added {
+		if err := p.songStorage.InsertSong(i+1, song); err != nil {
+			p.mu.Unlock()
+			p.logger.Error("Error al insertar canción en la lista de reproducción", zap.Error(err))
+			return nil, fmt.Errorf("al insertar canción: %w", err)
 		}
 	}
+	p.mu.Unlock()
+
+	p.refreshPrefetch()
+	p.triggerPlay(textChannelID, voiceChannelID)
 
+	p.logger.Info("Canciones agregadas al principio de la lista de reproducción", zap.Int("cantidad", len(result.Added)), zap.Int("descartadas", result.Rejected))
+	return result, nil
+}
+
+// triggerPlay envía un disparador de reproducción al bucle principal.
+func (p *GuildPlayer) triggerPlay(textChannelID, voiceChannelID *string) {
 	go func() {
 		p.triggerCh <- Trigger{
 			Command:        "play",
@@ -201,17 +436,163 @@ func (p *GuildPlayer) AddSong(textChannelID, voiceChannelID *string, songs ...*v
 			TextChannelID:  textChannelID,
 		}
 	}()
+}
+
+// MoveSong mueve una canción de la lista de reproducción a otra posición.
+func (p *GuildPlayer) MoveSong(from, to int) (*voice.Song, error) {
+	song, err := p.songStorage.MoveSong(from, to)
+	if err != nil {
+		p.logger.Error("Error al mover canción en la lista de reproducción", zap.Error(err))
+		return nil, fmt.Errorf("al mover canción: %w", err)
+	}
+	p.refreshPrefetch()
+
+	p.logger.Info("Canción movida en la lista de reproducción", zap.String("título", song.Title), zap.Int("desde", from), zap.Int("hasta", to))
+	return song, nil
+}
 
-	p.logger.Info("Canciones agregadas a la lista de reproducción", zap.Int("cantidad", len(songs)))
+// ShufflePlaylist mezcla aleatoriamente la lista de reproducción.
+func (p *GuildPlayer) ShufflePlaylist() error {
+	if err := p.songStorage.ShuffleSongs(); err != nil {
+		p.logger.Error("Error al mezclar la lista de reproducción", zap.Error(err))
+		return fmt.Errorf("al mezclar la lista de reproducción: %w", err)
+	}
+	p.refreshPrefetch()
+
+	p.logger.Info("Lista de reproducción mezclada")
+	return nil
+}
+
+// SkipSong salta la canción actual sin votación. Devuelve ErrNotPlaying si no hay una canción sonando.
+func (p *GuildPlayer) SkipSong() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.cancelSong(interruptionSkip) {
+		return ErrNotPlaying
+	}
+	p.logger.Info("Canción actual saltada")
 	return nil
 }
 
-// SkipSong salta la canción actual.
-func (p *GuildPlayer) SkipSong() {
-	if p.songCtxCancel != nil {
-		p.songCtxCancel()
-		p.logger.Info("Canción actual saltada")
+// cancelSong cancela la canción actual registrando el motivo. Debe llamarse con p.mu tomado.
+func (p *GuildPlayer) cancelSong(reason songInterruption) bool {
+	if p.songCtxCancel == nil {
+		return false
 	}
+	p.interruption = reason
+	p.songCtxCancel()
+	return true
+}
+
+// Pause pausa la canción actual sin descartar el flujo de audio.
+func (p *GuildPlayer) Pause() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	currentSong, err := p.stateStorage.GetCurrentSong()
+	if err != nil {
+		p.logger.Error("Error al obtener la canción actual", zap.Error(err))
+		return fmt.Errorf("al obtener la canción actual: %w", err)
+	}
+	if currentSong == nil {
+		return ErrNotPlaying
+	}
+
+	paused, err := p.stateStorage.GetPaused()
+	if err != nil {
+		p.logger.Error("Error al obtener el estado de pausa", zap.Error(err))
+		return fmt.Errorf("al obtener el estado de pausa: %w", err)
+	}
+	if paused {
+		return ErrAlreadyPaused
+	}
+
+	p.session.Pause()
+	if err := p.stateStorage.SetPaused(true); err != nil {
+		p.logger.Error("Error al establecer el estado de pausa", zap.Error(err))
+		return fmt.Errorf("al establecer el estado de pausa: %w", err)
+	}
+	p.refreshPlayMessage(currentSong)
+
+	p.logger.Info("Reproducción pausada", zap.String("título", currentSong.Title))
+	return nil
+}
+
+// Resume reanuda la canción actual desde la posición en la que se pausó.
+func (p *GuildPlayer) Resume() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	currentSong, err := p.stateStorage.GetCurrentSong()
+	if err != nil {
+		p.logger.Error("Error al obtener la canción actual", zap.Error(err))
+		return fmt.Errorf("al obtener la canción actual: %w", err)
+	}
+	if currentSong == nil {
+		return ErrNotPlaying
+	}
+
+	paused, err := p.stateStorage.GetPaused()
+	if err != nil {
+		p.logger.Error("Error al obtener el estado de pausa", zap.Error(err))
+		return fmt.Errorf("al obtener el estado de pausa: %w", err)
+	}
+	if !paused {
+		return ErrNotPaused
+	}
+
+	if err := p.stateStorage.SetPaused(false); err != nil {
+		p.logger.Error("Error al establecer el estado de pausa", zap.Error(err))
+		return fmt.Errorf("al establecer el estado de pausa: %w", err)
+	}
+	p.session.Resume()
+	p.refreshPlayMessage(currentSong)
+
+	p.logger.Info("Reproducción reanudada", zap.String("título", currentSong.Title))
+	return nil
+}
+
+// Seek reinicia la canción actual en la posición indicada.
+func (p *GuildPlayer) Seek(position time.Duration) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	currentSong, err := p.stateStorage.GetCurrentSong()
+	if err != nil {
+		p.logger.Error("Error al obtener la canción actual", zap.Error(err))
+		return fmt.Errorf("al obtener la canción actual: %w", err)
+	}
+	if currentSong == nil {
+		return ErrNotPlaying
+	}
+	if currentSong.Live {
+		return ErrSeekLive
+	}
+	if position < 0 || (currentSong.Duration > 0 && position >= currentSong.Duration) {
+		return ErrInvalidSeekPosition
+	}
+
+	if err := p.restartCurrentSong(currentSong, position); err != nil {
+		return err
+	}
+
+	p.logger.Info("Canción actual reposicionada", zap.String("título", currentSong.Title), zap.Duration("posición", position))
+	return nil
+}
+
+// restartCurrentSong vuelve a reproducir la canción actual desde la posición indicada. Debe llamarse con p.mu tomado.
+func (p *GuildPlayer) restartCurrentSong(currentSong *voice.PlayedSong, position time.Duration) error {
+	song := currentSong.Song
+	song.StartPosition = position
+	if err := p.songStorage.PrependSong(&song); err != nil {
+		p.logger.Error("Error al agregar la canción en la nueva posición", zap.Error(err))
+		return fmt.Errorf("al agregar canción: %w", err)
+	}
+	p.refreshPrefetch()
+
+	p.cancelSong(interruptionSeek)
+	return nil
 }
 
 // Stop detiene la reproducción y limpia la lista de reproducción.
@@ -220,15 +601,203 @@ func (p *GuildPlayer) Stop() error {
 		p.logger.Error("Error al limpiar la lista de reproducción", zap.Error(err))
 		return fmt.Errorf("al limpiar la lista de reproducción: %w", err)
 	}
+	p.refreshPrefetch()
 
-	if p.songCtxCancel != nil {
-		p.songCtxCancel()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.idleCancel != nil {
+		p.idleCancel()
+	}
+	if p.cancelSong(interruptionStop) {
 		p.logger.Info("Reproducción detenida y lista de reproducción limpia")
 	}
 
 	return nil
 }
 
+// SetLoopMode establece el modo de repetición del reproductor.
+func (p *GuildPlayer) SetLoopMode(mode voice.LoopMode) error {
+	if !mode.IsValid() {
+		return ErrInvalidLoopMode
+	}
+	if err := p.stateStorage.SetLoopMode(mode); err != nil {
+		p.logger.Error("Error al establecer el modo de repetición", zap.Error(err))
+		return fmt.Errorf("al establecer el modo de repetición: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	currentSong, err := p.stateStorage.GetCurrentSong()
+	if err != nil {
+		p.logger.Error("Error al obtener la canción actual", zap.Error(err))
+	} else if currentSong != nil {
+		p.refreshPlayMessage(currentSong)
+	}
+
+	p.logger.Info("Modo de repetición establecido", zap.String("modo", string(mode)))
+	return nil
+}
+
+// GetLoopMode devuelve el modo de repetición del reproductor.
+func (p *GuildPlayer) GetLoopMode() (voice.LoopMode, error) {
+	mode, err := p.stateStorage.GetLoopMode()
+	if err != nil {
+		p.logger.Error("Error al obtener el modo de repetición", zap.Error(err))
+		return "", fmt.Errorf("al obtener el modo de repetición: %w", err)
+	}
+	return mode, nil
+}
+
+// IsPaused indica si la canción actual está en pausa.
+func (p *GuildPlayer) IsPaused() (bool, error) {
+	paused, err := p.stateStorage.GetPaused()
+	if err != nil {
+		p.logger.Error("Error al obtener el estado de pausa", zap.Error(err))
+		return false, fmt.Errorf("al obtener el estado de pausa: %w", err)
+	}
+	return paused, nil
+}
+
+// SetVolume establece el volumen del reproductor en porcentaje.
+// Si hay una canción en reproducción, se vuelve a transmitir desde la posición actual con el nuevo volumen.
+func (p *GuildPlayer) SetVolume(volume int) error {
+	if volume < voice.MinVolume || volume > voice.MaxVolume {
+		return ErrInvalidVolume
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	current, err := p.stateStorage.GetVolume()
+	if err != nil {
+		p.logger.Error("Error al obtener el volumen", zap.Error(err))
+		return fmt.Errorf("al obtener el volumen: %w", err)
+	}
+	if err := p.stateStorage.SetVolume(volume); err != nil {
+		p.logger.Error("Error al establecer el volumen", zap.Error(err))
+		return fmt.Errorf("al establecer el volumen: %w", err)
+	}
+	p.logger.Info("Volumen establecido", zap.Int("volumen", volume))
+
+	if current == volume {
+		return nil
+	}
+
+	currentSong, err := p.stateStorage.GetCurrentSong()
+	if err != nil {
+		p.logger.Error("Error al obtener la canción actual", zap.Error(err))
+		return fmt.Errorf("al obtener la canción actual: %w", err)
+	}
+	if currentSong == nil {
+		return nil
+	}
+	return p.restartCurrentSong(currentSong, currentSong.Position)
+}
+
+// SetAutoplay activa o desactiva la reproducción automática cuando la lista de reproducción se vacía.
+func (p *GuildPlayer) SetAutoplay(enabled bool) error {
+	if err := p.stateStorage.SetAutoplay(enabled); err != nil {
+		p.logger.Error("Error al establecer la reproducción automática", zap.Error(err))
+		return fmt.Errorf("al establecer la reproducción automática: %w", err)
+	}
+
+	p.logger.Info("Reproducción automática establecida", zap.Bool("activada", enabled))
+	return nil
+}
+
+// autoplaySong obtiene una canción relacionada con la última reproducida si la reproducción automática está activada.
+// Retorna nil si no corresponde agregar ninguna canción.
+func (p *GuildPlayer) autoplaySong(ctx context.Context, lastSong *voice.Song) (*voice.Song, error) {
+	if lastSong == nil || p.relatedSong == nil {
+		return nil, nil
+	}
+
+	enabled, err := p.stateStorage.GetAutoplay()
+	if err != nil {
+		return nil, fmt.Errorf("al obtener la reproducción automática: %w", err)
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	history, err := p.historyStorage.GetSongs()
+	if err != nil {
+		return nil, fmt.Errorf("al obtener el historial: %w", err)
+	}
+	recent := make([]string, len(history))
+	for i, song := range history {
+		recent[i] = song.URL
+	}
+
+	song, err := p.relatedSong(ctx, lastSong, recent)
+	if err != nil {
+		return nil, fmt.Errorf("al obtener una canción relacionada: %w", err)
+	}
+	return song, nil
+}
+
+// requeueForLoop vuelve a agregar la canción terminada a la lista de reproducción según el modo de repetición.
+func (p *GuildPlayer) requeueForLoop(song *voice.Song, interruption songInterruption) error {
+	mode, err := p.stateStorage.GetLoopMode()
+	if err != nil {
+		return fmt.Errorf("al obtener el modo de repetición: %w", err)
+	}
+
+	replay := *song
+	replay.StartPosition = 0
+
+	switch mode {
+	case voice.LoopModeTrack:
+		// Saltar, detener o reposicionar la canción rompe la repetición de la pista.
+		if interruption == interruptionNone {
+			return p.songStorage.PrependSong(&replay)
+		}
+	case voice.LoopModeQueue:
+		// Las canciones saltadas siguen formando parte de la lista que se repite. Vuelven al final sin pasar por
+		// la cola justa, que las intercalaría entre las demás y cambiaría el orden de la lista.
+		if interruption == interruptionNone || interruption == interruptionSkip {
+			return p.songStorage.AppendSongUnfair(&replay)
+		}
+	}
+	return nil
+}
+
+// GetHistory obtiene las canciones reproducidas recientemente, de la más reciente a la más antigua.
+func (p *GuildPlayer) GetHistory() ([]*voice.Song, error) {
+	songs, err := p.historyStorage.GetSongs()
+	if err != nil {
+		p.logger.Error("Error al obtener el historial", zap.Error(err))
+		return nil, fmt.Errorf("al obtener el historial: %w", err)
+	}
+
+	p.logger.Info("Historial obtenido", zap.Int("cantidad", len(songs)))
+	return songs, nil
+}
+
+// PlayPrevious quita la última canción reproducida del historial y la agrega al principio de la lista de reproducción.
+func (p *GuildPlayer) PlayPrevious(textChannelID, voiceChannelID *string) (*voice.Song, error) {
+	song, err := p.historyStorage.PopLastSong()
+	if err != nil {
+		if errors.Is(err, ErrEmptyHistory) {
+			return nil, err
+		}
+		p.logger.Error("Error al obtener la canción anterior", zap.Error(err))
+		return nil, fmt.Errorf("al obtener la canción anterior: %w", err)
+	}
+
+	if _, err := p.AddSongNext(textChannelID, voiceChannelID, song); err != nil {
+		// La canción vuelve al historial para poder pedirla más adelante.
+		if err := p.historyStorage.AddSong(song); err != nil {
+			p.logger.Error("Error al devolver la canción al historial", zap.Error(err))
+		}
+		return nil, err
+	}
+
+	p.logger.Info("Canción anterior agregada al principio de la lista de reproducción", zap.String("título", song.Title))
+	return song, nil
+}
+
 // RemoveSong elimina una canción de la lista de reproducción por posición.
 func (p *GuildPlayer) RemoveSong(position int) (*voice.Song, error) {
 	song, err := p.songStorage.RemoveSong(position)
@@ -236,26 +805,36 @@ func (p *GuildPlayer) RemoveSong(position int) (*voice.Song, error) {
 		p.logger.Error("Error al eliminar canción de la lista de reproducción", zap.Error(err))
 		return nil, fmt.Errorf("al eliminar canción: %w", err)
 	}
+	p.refreshPrefetch()
 
 	p.logger.Info("Canción eliminada de la lista de reproducción", zap.String("título", song.Title))
 	return song, nil
 }
 
+// RemoveRequesterSong elimina una canción de la lista de reproducción por posición, solo si la agregó el usuario
+// indicado. Retorna ErrNotSongOwner si la canción de esa posición es de otro usuario.
+func (p *GuildPlayer) RemoveRequesterSong(position int, requesterID string) (*voice.Song, error) {
+	song, err := p.songStorage.RemoveRequesterSong(position, requesterID)
+	if err != nil {
+		p.logger.Info("No se eliminó la canción de la lista de reproducción", zap.Error(err))
+		return nil, fmt.Errorf("al eliminar canción: %w", err)
+	}
+	p.refreshPrefetch()
+
+	p.logger.Info("Canción eliminada de la lista de reproducción", zap.String("título", song.Title), zap.String("solicitante", requesterID))
+	return song, nil
+}
+
 // GetPlaylist obtiene la lista de reproducción actual.
-func (p *GuildPlayer) GetPlaylist() ([]string, error) {
+func (p *GuildPlayer) GetPlaylist() ([]*voice.Song, error) {
 	songs, err := p.songStorage.GetSongs()
 	if err != nil {
 		p.logger.Error("Error al obtener la lista de reproducción", zap.Error(err))
 		return nil, fmt.Errorf("al obtener canciones: %w", err)
 	}
 
-	playlist := make([]string, len(songs))
-	for i, song := range songs {
-		playlist[i] = song.GetHumanName()
-	}
-
-	p.logger.Info("Lista de reproducción obtenida", zap.Int("cantidad", len(playlist)))
-	return playlist, nil
+	p.logger.Info("Lista de reproducción obtenida", zap.Int("cantidad", len(songs)))
+	return songs, nil
 }
 
 // GetPlayedSong obtiene la canción que se está reproduciendo actualmente.
@@ -282,12 +861,20 @@ func (p *GuildPlayer) Run(ctx context.Context) error {
 		return err
 	}
 	if currentSong != nil {
-		currentSong.StartPosition += currentSong.Position
+		// La posición guardada ya incluye la posición de inicio de la canción.
+		currentSong.StartPosition = currentSong.Position
 		if err := p.songStorage.PrependSong(&currentSong.Song); err != nil {
 			p.logger.Info("falló al agregar la canción actual en la lista de reproducción", zap.Error(err))
 			return err
 		}
+		// La canción ya volvió a la lista de reproducción; si se mantuviera como actual, otro reinicio la duplicaría.
+		if err := p.stateStorage.SetCurrentSong(nil); err != nil {
+			p.logger.Info("falló al limpiar la canción actual", zap.Error(err))
+			return err
+		}
 	}
+	// La pausa no sobrevive al reinicio: la canción se retoma sonando.
+	p.resetPause()
 
 	// Reproducir la lista de reproducción si hay canciones
 	songs, err := p.songStorage.GetSongs()
@@ -343,24 +930,62 @@ func (p *GuildPlayer) playPlaylist(ctx context.Context) error {
 	}
 
 	defer func() {
+		if p.prefetcher != nil {
+			p.prefetcher.stop()
+		}
 		p.logger.Info("saliendo del canal de voz", zap.String("canal", voiceChannel))
 		if err := p.session.LeaveVoiceChannel(); err != nil {
 			p.logger.Error("Error falló al salir del canal de voz", zap.Error(err))
 		}
+		// La información de presencia deja de ser válida al salir del canal de voz.
+		p.mu.Lock()
+		p.voiceChannelMap = make(map[string]VoiceChannelInfo)
+		p.mu.Unlock()
 	}()
 
+	p.mu.Lock()
+	p.leaveRequested = false
+	p.mu.Unlock()
+
+	var lastSong *voice.Song
+	consecutiveFailures := 0
 	for {
 		song, err := p.songStorage.PopFirstSong()
 		if errors.Is(err, ErrNoSongs) {
 			p.logger.Info("la lista de reproducción está vacía")
-			break
+			p.events.Publish(QueueEmptiedEvent{})
+			related, err := p.autoplaySong(ctx, lastSong)
+			if err != nil {
+				p.logger.Error("Error en la reproducción automática", zap.Error(err))
+			}
+			if related != nil {
+				p.logger.Info("Canción agregada por la reproducción automática", zap.String("título", related.Title))
+				if err := p.songStorage.AppendSong(related); err != nil {
+					p.logger.Error("Error al agregar la canción de la reproducción automática", zap.Error(err))
+					return err
+				}
+				continue
+			}
+
+			p.mu.Lock()
+			leaving := p.leaveRequested
+			p.mu.Unlock()
+			if leaving || !p.waitForSongs(ctx) {
+				break
+			}
+			// El canal de texto puede haber cambiado con el nuevo disparador.
+			if textChannel, err = p.stateStorage.GetTextChannel(); err != nil {
+				p.logger.Error("Error al obtener el canal de texto", zap.Error(err))
+				return err
+			}
+			continue
 		}
 		if err != nil {
 			p.logger.Error("Error al obtener la primera cancion", zap.Error(err))
 			return err
 		}
 
-		if err := p.stateStorage.SetCurrentSong(&voice.PlayedSong{Song: *song}); err != nil {
+		if err := p.stateStorage.SetCurrentSong(&voice.PlayedSong{Song: *song, Position: song.StartPosition}); err != nil {
 			p.logger.Error("Error al establecer la cancion actual", zap.Error(err))
 			return err
 		}
@@ -368,35 +993,115 @@ func (p *GuildPlayer) playPlaylist(ctx context.Context) error {
 		songCtx, cancel := context.WithCancel(ctx)
 		p.mu.Lock()
 		p.songCtxCancel = cancel
+		p.interruption = interruptionNone
+		p.resetSkipVotes()
 		p.mu.Unlock()
 
 		p.logger.With(zap.String("título", song.Title), zap.String("URL", song.URL))
 
-		playMsgID, err := p.message.SendPlayMessage(textChannel, &voice.PlayMessage{Song: song})
+		playMsgID, err := p.message.SendPlayMessage(textChannel, p.playMessage(song, song.StartPosition))
 		if err != nil {
 			p.logger.Error("Error al enviar el mensaje con el nombre de la cancion", zap.Error(err))
 			return err
 		}
+		p.mu.Lock()
+		p.playMsgID = playMsgID
+		p.mu.Unlock()
+		p.events.Publish(SongStartedEvent{Song: song, TextChannelID: textChannel})
 
-		dcaData, err := p.dCADataGetter(songCtx, song)
+		volume, err := p.stateStorage.GetVolume()
 		if err != nil {
-			p.logger.Error("Error al obtener datos DCA de la cancion", zap.Any("Cancion", song), zap.Error(err))
+			p.logger.Error("Error al obtener el volumen", zap.Error(err))
 			return err
 		}
+		// Se usa una copia para no modificar canciones compartidas con la caché de búsquedas.
+		streamSong := *song
+		streamSong.Volume = &volume
+
+		// Si la precarga ya terminó, el audio está en la caché. Si sigue en curso se cancela: esperar a que termine
+		// la descarga completa demoraría el comienzo de la canción, que se transmite a medida que se descarga.
+		if p.prefetcher != nil {
+			p.prefetcher.stop()
+		}
+		dcaData, err := p.dCADataGetter(songCtx, &streamSong)
+		if err != nil {
+			cancel()
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			p.finishPlayMessage(song, song.StartPosition, textChannel, playMsgID)
+			// La canción se saltó o se detuvo mientras se obtenía el audio: no es un fallo.
+			if songCtx.Err() != nil {
+				p.resetPause()
+				if err := p.stateStorage.SetCurrentSong(nil); err != nil {
+					p.logger.Error("Error al establecer la cancion actual", zap.Error(err))
+				}
+				p.mu.Lock()
+				if p.interruption == interruptionStop {
+					lastSong = nil
+				}
+				p.mu.Unlock()
+				continue
+			}
+			consecutiveFailures++
+			if err := p.handleSongFailure(song, textChannel, err, consecutiveFailures); err != nil {
+				return err
+			}
+			continue
+		}
+		titler, _ := dcaData.(voice.StreamTitler)
 		audioReader := bufio.NewReaderSize(dcaData, p.audioBufferSize)
 		p.logger.Info("enviando flujo de audio")
-		if err := p.session.SendAudio(songCtx, audioReader, func(d time.Duration) {
-			p.updateSongPosition(song, d, textChannel, playMsgID)
-		}); err != nil {
-			p.logger.Error("Error al enviar datos de audio", zap.Error(err))
-			return err
+		err = p.session.SendAudio(songCtx, audioReader, func(d time.Duration) {
+			var streamTitle string
+			if titler != nil {
+				streamTitle = titler.StreamTitle()
+			}
+			p.updateSongPosition(song, song.StartPosition+d, streamTitle, textChannel, playMsgID)
+			p.maybePrefetch(ctx, song, song.StartPosition+d)
+			p.events.Publish(SongProgressEvent{Song: song, Position: song.StartPosition + d})
+		})
+		p.resetPause()
+		if err != nil {
+			cancel()
+			p.finishPlayMessage(song, song.StartPosition, textChannel, playMsgID)
+			consecutiveFailures++
+			if err := p.handleSongFailure(song, textChannel, err, consecutiveFailures); err != nil {
+				return err
+			}
+			continue
 		}
+		consecutiveFailures = 0
 		p.logger.Info("Reproduccion detenida")
-		p.updateSongPosition(song, song.Duration, textChannel, playMsgID)
+		p.finishPlayMessage(song, song.Duration, textChannel, playMsgID)
 		if err := p.stateStorage.SetCurrentSong(nil); err != nil {
 			p.logger.Error("Error al establecer la cancion actual", zap.Error(err))
 			return err
 		}
+
+		p.mu.Lock()
+		interruption := p.interruption
+		p.mu.Unlock()
+		p.events.Publish(SongFinishedEvent{Song: song, Reason: interruption.finishReason()})
+		if err := p.requeueForLoop(song, interruption); err != nil {
+			p.logger.Error("Error al volver a agregar la canción en repetición", zap.Error(err))
+			return err
+		}
+
+		// Reposicionar la canción no la termina, por lo que no se registra en el historial.
+		if interruption != interruptionSeek {
+			played := *song
+			played.StartPosition = 0
+			if err := p.historyStorage.AddSong(&played); err != nil {
+				p.logger.Error("Error al agregar la canción al historial", zap.Error(err))
+			}
+		}
+
+		// Detener la reproducción no debe disparar la reproducción automática.
+		lastSong = song
+		if interruption == interruptionStop {
+			lastSong = nil
+		}
 		time.Sleep(250 * time.Millisecond)
 	}
 	p.logger.Info("playPlaylist finalizado")
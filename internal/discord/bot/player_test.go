@@ -0,0 +1,544 @@
+package bot_test
+
+import (
+	"context"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot/store"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot/store/inmemory_storage"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/discordmessenger"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testTimeout es lo máximo que se espera a que el reproductor reaccione en las pruebas.
+const testTimeout = 2 * time.Second
+
+// fakeVoiceSession es una sesión de voz de prueba. Como el DCAStreamer, no entrega el primer frame mientras
+// está en pausa, y cada canción suena hasta que se cancela o hasta que la prueba la termina con finish.
+type fakeVoiceSession struct {
+	mu       sync.Mutex
+	paused   bool
+	resumeCh chan struct{}
+	started  chan string   // Recibe el audio de cada canción que empieza a sonar.
+	finish   chan struct{} // Termina la canción actual como si se hubiera acabado el audio.
+	left     chan struct{} // Recibe un valor cada vez que el bot sale del canal de voz.
+}
+
+func newFakeVoiceSession() *fakeVoiceSession {
+	return &fakeVoiceSession{
+		started: make(chan string, 10),
+		finish:  make(chan struct{}),
+		left:    make(chan struct{}, 10),
+	}
+}
+
+func (s *fakeVoiceSession) Close() error                  { return nil }
+func (s *fakeVoiceSession) JoinVoiceChannel(string) error { return nil }
+
+func (s *fakeVoiceSession) LeaveVoiceChannel() error {
+	s.left <- struct{}{}
+	return nil
+}
+
+func (s *fakeVoiceSession) SendAudio(ctx context.Context, reader io.Reader, positionCallback func(time.Duration)) error {
+	// La pausa no se traslada a la siguiente canción, igual que en el DCAStreamer.
+	defer s.Resume()
+
+	s.mu.Lock()
+	resumeCh := s.resumeCh
+	paused := s.paused
+	s.mu.Unlock()
+	if paused {
+		select {
+		case <-resumeCh:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	s.started <- string(data)
+	positionCallback(time.Second)
+
+	select {
+	case <-ctx.Done():
+	case <-s.finish:
+	}
+	return nil
+}
+
+func (s *fakeVoiceSession) Pause() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.paused {
+		s.paused = true
+		s.resumeCh = make(chan struct{})
+	}
+}
+
+func (s *fakeVoiceSession) Resume() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.paused {
+		s.paused = false
+		close(s.resumeCh)
+	}
+}
+
+func (s *fakeVoiceSession) isPaused() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.paused
+}
+
+// fakeAudio entrega como audio de cada canción su URL y registra las canciones pedidas. El audio de las URLs
+// bloqueadas no llega nunca, como una descarga lenta que se cancela al saltar la canción.
+type fakeAudio struct {
+	requests chan voice.Song
+	blocked  map[string]bool
+}
+
+func newFakeAudio(blocked ...string) *fakeAudio {
+	audio := &fakeAudio{requests: make(chan voice.Song, 10), blocked: make(map[string]bool)}
+	for _, url := range blocked {
+		audio.blocked[url] = true
+	}
+	return audio
+}
+
+func (a *fakeAudio) get(ctx context.Context, song *voice.Song) (io.Reader, error) {
+	a.requests <- *song
+	if a.blocked[song.URL] {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return strings.NewReader(song.URL), nil
+}
+
+// silentMessageSender es un mensajero de prueba que acepta todos los mensajes sin enviarlos.
+type silentMessageSender struct {
+	discordmessenger.ChatMessageSender
+}
+
+func (silentMessageSender) SendMessage(string, string) error { return nil }
+
+func (silentMessageSender) SendPlayMessage(string, *voice.PlayMessage) (string, error) {
+	return "mensaje", nil
+}
+
+func (silentMessageSender) EditPlayMessage(string, string, *voice.PlayMessage) error { return nil }
+
+func (silentMessageSender) SendEmbed(string, *discordgo.MessageEmbed) (string, error) {
+	return "votos", nil
+}
+
+func (silentMessageSender) EditEmbed(string, string, *discordgo.MessageEmbed) error { return nil }
+
+// testPlayer es un reproductor corriendo con almacenamiento en memoria y una sesión de voz de prueba.
+type testPlayer struct {
+	*bot.GuildPlayer
+	session *fakeVoiceSession
+	audio   *fakeAudio
+}
+
+// newTestLogger crea un logger de prueba que acepta todos los mensajes.
+func newTestLogger() *logging.MockLogger {
+	logger := new(logging.MockLogger)
+	for _, method := range []string{"Info", "Warn", "Error", "Debug"} {
+		logger.On(method, mock.Anything, mock.Anything).Return()
+	}
+	logger.On("With", mock.Anything).Return()
+	return logger
+}
+
+// newTestPlayer crea el reproductor y corre su bucle principal hasta que termina la prueba.
+func newTestPlayer(t *testing.T, audio *fakeAudio, configure func(*bot.GuildPlayer)) *testPlayer {
+	return newTestPlayerWithSongs(t, inmemory_storage.NewInmemorySongStorage(newTestLogger()), audio, configure)
+}
+
+// newTestPlayerWithSongs crea el reproductor con la lista de reproducción indicada y corre su bucle principal
+// hasta que termina la prueba.
+func newTestPlayerWithSongs(t *testing.T, songStorage store.SongStorage, audio *fakeAudio, configure func(*bot.GuildPlayer)) *testPlayer {
+	logger := newTestLogger()
+	session := newFakeVoiceSession()
+	player := bot.NewGuildPlayer(
+		session,
+		songStorage,
+		inmemory_storage.NewInmemoryStateStorage(logger),
+		inmemory_storage.NewInmemoryHistoryStorage(logger, 10),
+		audio.get,
+		silentMessageSender{},
+		logger,
+	)
+	if configure != nil {
+		configure(player)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = player.Run(ctx) }()
+	return &testPlayer{GuildPlayer: player, session: session, audio: audio}
+}
+
+// add agrega canciones con las URLs indicadas al final de la lista de reproducción.
+func (p *testPlayer) add(t *testing.T, urls ...string) {
+	textChannel, voiceChannel := "texto", "voz"
+	songs := make([]*voice.Song, len(urls))
+	for i, url := range urls {
+		songs[i] = &voice.Song{Title: url, URL: url, Duration: 3 * time.Minute}
+	}
+	_, err := p.AddSong(&textChannel, &voiceChannel, songs...)
+	assert.NoError(t, err)
+}
+
+// expectStarted espera a que empiece a sonar la canción con la URL indicada.
+func (p *testPlayer) expectStarted(t *testing.T, url string) {
+	t.Helper()
+	select {
+	case started := <-p.session.started:
+		assert.Equal(t, url, started)
+	case <-time.After(testTimeout):
+		t.Fatalf("la canción %s no empezó a sonar", url)
+	}
+}
+
+// expectRequested espera a que el reproductor pida el audio de la canción con la URL indicada y la devuelve.
+func (p *testPlayer) expectRequested(t *testing.T, url string) voice.Song {
+	t.Helper()
+	select {
+	case song := <-p.audio.requests:
+		assert.Equal(t, url, song.URL)
+		return song
+	case <-time.After(testTimeout):
+		t.Fatalf("no se pidió el audio de %s", url)
+		return voice.Song{}
+	}
+}
+
+// finish termina la canción actual como si se hubiera acabado su audio.
+func (p *testPlayer) finish(t *testing.T) {
+	t.Helper()
+	select {
+	case p.session.finish <- struct{}{}:
+	case <-time.After(testTimeout):
+		t.Fatal("no hay ninguna canción sonando")
+	}
+}
+
+// playlistURLs devuelve las URLs de la lista de reproducción en orden.
+func (p *testPlayer) playlistURLs(t *testing.T) []string {
+	songs, err := p.GetPlaylist()
+	assert.NoError(t, err)
+	urls := make([]string, len(songs))
+	for i, song := range songs {
+		urls[i] = song.URL
+	}
+	return urls
+}
+
+func TestGuildPlayer_PauseResume(t *testing.T) {
+	player := newTestPlayer(t, newFakeAudio(), nil)
+	player.add(t, "uno", "dos")
+	player.expectRequested(t, "uno")
+	player.expectStarted(t, "uno")
+
+	assert.NoError(t, player.Pause())
+	assert.ErrorIs(t, player.Pause(), bot.ErrAlreadyPaused)
+	paused, err := player.IsPaused()
+	assert.NoError(t, err)
+	assert.True(t, paused)
+	assert.True(t, player.session.isPaused())
+
+	assert.NoError(t, player.Resume())
+	assert.ErrorIs(t, player.Resume(), bot.ErrNotPaused)
+	assert.False(t, player.session.isPaused())
+
+	player.finish(t)
+	player.expectRequested(t, "dos")
+	player.expectStarted(t, "dos")
+}
+
+func TestGuildPlayer_SkipWhilePausedAndLoading(t *testing.T) {
+	player := newTestPlayer(t, newFakeAudio("lenta"), nil)
+	player.add(t, "lenta", "siguiente")
+	player.expectRequested(t, "lenta")
+
+	// La canción se pausa y se salta antes de que llegue su audio.
+	assert.NoError(t, player.Pause())
+	player.SkipSong()
+
+	player.expectRequested(t, "siguiente")
+	player.expectStarted(t, "siguiente")
+	paused, err := player.IsPaused()
+	assert.NoError(t, err)
+	assert.False(t, paused)
+}
+
+func TestGuildPlayer_Seek(t *testing.T) {
+	player := newTestPlayer(t, newFakeAudio(), nil)
+	assert.ErrorIs(t, player.Seek(time.Minute), bot.ErrNotPlaying)
+
+	player.add(t, "uno", "dos")
+	player.expectRequested(t, "uno")
+	player.expectStarted(t, "uno")
+
+	assert.ErrorIs(t, player.Seek(5*time.Minute), bot.ErrInvalidSeekPosition)
+	assert.NoError(t, player.Seek(90*time.Second))
+
+	// La canción vuelve a pedirse desde la nueva posición, antes que el resto de la lista.
+	song := player.expectRequested(t, "uno")
+	assert.Equal(t, 90*time.Second, song.StartPosition)
+	player.expectStarted(t, "uno")
+	assert.Equal(t, []string{"dos"}, player.playlistURLs(t))
+
+	// Reposicionar no termina la canción, así que no pasa al historial.
+	history, err := player.GetHistory()
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestGuildPlayer_LoopTrack(t *testing.T) {
+	player := newTestPlayer(t, newFakeAudio(), nil)
+	assert.NoError(t, player.SetLoopMode(voice.LoopModeTrack))
+	player.add(t, "uno", "dos")
+	player.expectRequested(t, "uno")
+	player.expectStarted(t, "uno")
+
+	// Al terminar, la canción se repite.
+	player.finish(t)
+	player.expectRequested(t, "uno")
+	player.expectStarted(t, "uno")
+
+	// Saltarla rompe la repetición y pasa a la siguiente.
+	player.SkipSong()
+	player.expectRequested(t, "dos")
+	player.expectStarted(t, "dos")
+	assert.Empty(t, player.playlistURLs(t))
+}
+
+func TestGuildPlayer_LoopQueue(t *testing.T) {
+	player := newTestPlayer(t, newFakeAudio(), nil)
+	assert.NoError(t, player.SetLoopMode(voice.LoopModeQueue))
+	player.add(t, "uno", "dos", "tres")
+	player.expectRequested(t, "uno")
+	player.expectStarted(t, "uno")
+
+	// Tanto al terminar como al saltarla, la canción vuelve al final de la lista.
+	player.finish(t)
+	player.expectRequested(t, "dos")
+	player.expectStarted(t, "dos")
+	assert.Equal(t, []string{"tres", "uno"}, player.playlistURLs(t))
+
+	player.SkipSong()
+	player.expectRequested(t, "tres")
+	player.expectStarted(t, "tres")
+	assert.Equal(t, []string{"uno", "dos"}, player.playlistURLs(t))
+
+	// Detener la reproducción no la vuelve a agregar.
+	assert.NoError(t, player.Stop())
+	select {
+	case <-player.session.left:
+	case <-time.After(testTimeout):
+		t.Fatal("el reproductor no salió del canal de voz")
+	}
+	assert.Empty(t, player.playlistURLs(t))
+}
+
+func TestGuildPlayer_LoopQueueIgnoresFairQueue(t *testing.T) {
+	songStorage := inmemory_storage.NewInmemorySongStorage(newTestLogger()).WithFairQueue(true)
+	player := newTestPlayerWithSongs(t, songStorage, newFakeAudio(), nil)
+	assert.NoError(t, player.SetLoopMode(voice.LoopModeQueue))
+
+	textChannel, voiceChannel := "texto", "voz"
+	var songs []*voice.Song
+	for _, song := range []struct{ url, requester string }{{"a1", "ana"}, {"b1", "beto"}, {"b2", "beto"}, {"b3", "beto"}} {
+		songs = append(songs, &voice.Song{Title: song.url, URL: song.url, Duration: 3 * time.Minute, RequesterID: song.requester})
+	}
+	_, err := player.AddSong(&textChannel, &voiceChannel, songs...)
+	assert.NoError(t, err)
+	player.expectRequested(t, "a1")
+	player.expectStarted(t, "a1")
+
+	// La canción repetida vuelve al final, aunque en la cola justa le tocaría ir antes que b2.
+	player.finish(t)
+	player.expectRequested(t, "b1")
+	player.expectStarted(t, "b1")
+	assert.Equal(t, []string{"b2", "b3", "a1"}, player.playlistURLs(t))
+}
+
+func TestGuildPlayer_PlayNextAndMove(t *testing.T) {
+	player := newTestPlayer(t, newFakeAudio(), nil)
+	player.add(t, "uno", "dos", "tres")
+	player.expectRequested(t, "uno")
+	player.expectStarted(t, "uno")
+
+	textChannel, voiceChannel := "texto", "voz"
+	_, err := player.AddSongNext(&textChannel, &voiceChannel,
+		&voice.Song{Title: "a", URL: "a", Duration: time.Minute},
+		&voice.Song{Title: "b", URL: "b", Duration: time.Minute},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "dos", "tres"}, player.playlistURLs(t))
+
+	song, err := player.MoveSong(4, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "tres", song.URL)
+	assert.Equal(t, []string{"tres", "a", "b", "dos"}, player.playlistURLs(t))
+
+	_, err = player.MoveSong(5, 1)
+	assert.Error(t, err)
+
+	// La siguiente canción que suena es la que quedó primera.
+	player.finish(t)
+	player.expectRequested(t, "tres")
+	player.expectStarted(t, "tres")
+}
+
+func TestGuildPlayer_SetVolume(t *testing.T) {
+	player := newTestPlayer(t, newFakeAudio(), nil)
+	assert.ErrorIs(t, player.SetVolume(-1), bot.ErrInvalidVolume)
+	assert.ErrorIs(t, player.SetVolume(voice.MaxVolume+1), bot.ErrInvalidVolume)
+
+	player.add(t, "uno", "dos")
+	song := player.expectRequested(t, "uno")
+	assert.Equal(t, voice.DefaultVolume, song.GetVolume())
+	player.expectStarted(t, "uno")
+
+	// Cambiar el volumen vuelve a transmitir la canción desde donde iba, con el nuevo volumen.
+	assert.NoError(t, player.SetVolume(150))
+	song = player.expectRequested(t, "uno")
+	assert.Equal(t, 150, song.GetVolume())
+	assert.Equal(t, time.Second, song.StartPosition)
+	player.expectStarted(t, "uno")
+	assert.Equal(t, []string{"dos"}, player.playlistURLs(t))
+
+	// Repetir el mismo volumen no reinicia la canción.
+	assert.NoError(t, player.SetVolume(150))
+	player.finish(t)
+	song = player.expectRequested(t, "dos")
+	assert.Equal(t, 150, song.GetVolume())
+	player.expectStarted(t, "dos")
+
+	// El cero silencia la canción sin confundirse con un volumen sin establecer.
+	assert.NoError(t, player.SetVolume(0))
+	song = player.expectRequested(t, "dos")
+	assert.Equal(t, 0, song.GetVolume())
+}
+
+func TestGuildPlayer_NextSongDoesNotWaitForPrefetch(t *testing.T) {
+	prefetching := make(chan string, 1)
+	cancelled := make(chan struct{})
+	prefetch := func(ctx context.Context, song *voice.Song) error {
+		prefetching <- song.URL
+		// La descarga es lenta y solo termina al cancelarse.
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	}
+	player := newTestPlayer(t, newFakeAudio(), func(p *bot.GuildPlayer) {
+		p.WithPrefetcher(prefetch, 0.5)
+	})
+
+	// El fakeVoiceSession informa un segundo de reproducción, la mitad de estas canciones.
+	textChannel, voiceChannel := "texto", "voz"
+	_, err := player.AddSong(&textChannel, &voiceChannel,
+		&voice.Song{Title: "uno", URL: "uno", Duration: 2 * time.Second},
+		&voice.Song{Title: "dos", URL: "dos", Duration: 2 * time.Second},
+	)
+	assert.NoError(t, err)
+	player.expectRequested(t, "uno")
+	player.expectStarted(t, "uno")
+
+	select {
+	case url := <-prefetching:
+		assert.Equal(t, "dos", url)
+	case <-time.After(testTimeout):
+		t.Fatal("no se precargó la próxima canción")
+	}
+
+	// La siguiente canción empieza sin esperar a que termine la precarga, que se cancela.
+	player.finish(t)
+	player.expectRequested(t, "dos")
+	player.expectStarted(t, "dos")
+	select {
+	case <-cancelled:
+	case <-time.After(testTimeout):
+		t.Fatal("la precarga no se canceló")
+	}
+}
+
+func TestGuildPlayer_LeavesAfterAloneGracePeriod(t *testing.T) {
+	player := newTestPlayer(t, newFakeAudio(), func(p *bot.GuildPlayer) {
+		// La espera de nuevas canciones es mucho más larga que la prueba: salir no debe esperarla.
+		p.WithIdlePolicy(bot.IdlePolicy{QueueEmptyTimeout: time.Hour, AloneGracePeriod: 50 * time.Millisecond})
+	})
+	player.add(t, "uno", "dos")
+	player.expectRequested(t, "uno")
+	player.expectStarted(t, "uno")
+
+	player.HandleAlone(true)
+	paused, err := player.IsPaused()
+	assert.NoError(t, err)
+	assert.True(t, paused)
+
+	select {
+	case <-player.session.left:
+	case <-time.After(testTimeout):
+		t.Fatal("el reproductor no salió del canal de voz después del período de gracia")
+	}
+	assert.Empty(t, player.playlistURLs(t))
+}
+
+func TestGuildPlayer_StaysWhenSomeoneReturns(t *testing.T) {
+	player := newTestPlayer(t, newFakeAudio(), func(p *bot.GuildPlayer) {
+		p.WithIdlePolicy(bot.IdlePolicy{QueueEmptyTimeout: time.Hour, AloneGracePeriod: 50 * time.Millisecond})
+	})
+	player.add(t, "uno")
+	player.expectRequested(t, "uno")
+	player.expectStarted(t, "uno")
+
+	player.HandleAlone(true)
+	player.HandleAlone(false)
+	paused, err := player.IsPaused()
+	assert.NoError(t, err)
+	assert.False(t, paused)
+
+	select {
+	case <-player.session.left:
+		t.Fatal("el reproductor no debería salir si alguien volvió al canal de voz")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestGuildPlayer_VoteSkip_ResetsVotesOnSongChange(t *testing.T) {
+	player := newTestPlayer(t, newFakeAudio(), func(p *bot.GuildPlayer) { p.WithVoteSkip(1) })
+	player.SetListeners("a", "b")
+	player.add(t, "uno", "dos")
+	player.expectStarted(t, "uno")
+
+	result, err := player.VoteSkip("a")
+	assert.NoError(t, err)
+	assert.Equal(t, &bot.SkipVoteResult{Votes: 1, Required: 2}, result)
+
+	// Los votos de la canción anterior no cuentan para la siguiente.
+	player.finish(t)
+	player.expectStarted(t, "dos")
+	result, err = player.VoteSkip("a")
+	assert.NoError(t, err)
+	assert.Equal(t, &bot.SkipVoteResult{Votes: 1, Required: 2}, result)
+
+	result, err = player.VoteSkip("b")
+	assert.NoError(t, err)
+	assert.True(t, result.Skipped)
+}
@@ -0,0 +1,81 @@
+package bot
+
+import (
+	"context"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"go.uber.org/zap"
+	"sync"
+)
+
+// PrefetchFunc es una función para descargar por adelantado los datos de audio de una canción.
+type PrefetchFunc func(ctx context.Context, song *voice.Song) error
+
+// prefetcher precarga el audio de la próxima canción mientras suena la actual.
+// Solo hay una precarga en curso a la vez, identificada por la URL de la canción.
+type prefetcher struct {
+	mu        sync.Mutex
+	fetch     PrefetchFunc
+	threshold float64 // Fracción de la canción actual a partir de la cual se precarga la siguiente.
+	logger    logging.Logger
+	url       string             // URL de la canción que se está precargando.
+	cancel    context.CancelFunc // Cancela la precarga en curso.
+}
+
+func newPrefetcher(fetch PrefetchFunc, threshold float64, logger logging.Logger) *prefetcher {
+	return &prefetcher{
+		fetch:     fetch,
+		threshold: threshold,
+		logger:    logger,
+	}
+}
+
+// start comienza la precarga de la canción si no se está precargando ya.
+func (f *prefetcher) start(ctx context.Context, song *voice.Song) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.url == song.URL {
+		return
+	}
+	f.stopLocked()
+
+	ctx, cancel := context.WithCancel(ctx)
+	f.url = song.URL
+	f.cancel = cancel
+
+	f.logger.Info("Precargando la próxima canción", zap.String("título", song.Title))
+	go func() {
+		defer cancel()
+		if err := f.fetch(ctx, song); err != nil && ctx.Err() == nil {
+			f.logger.Error("Error al precargar la próxima canción", zap.String("título", song.Title), zap.Error(err))
+		}
+	}()
+}
+
+// invalidate cancela la precarga en curso si ya no corresponde a la próxima canción de la lista.
+func (f *prefetcher) invalidate(nextURL string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.url == "" || f.url == nextURL {
+		return
+	}
+	f.logger.Info("Cancelando precarga por cambio en la lista de reproducción", zap.String("URL", f.url))
+	f.stopLocked()
+}
+
+// stop cancela la precarga en curso.
+func (f *prefetcher) stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stopLocked()
+}
+
+// stopLocked cancela la precarga en curso. Debe llamarse con f.mu tomado.
+func (f *prefetcher) stopLocked() {
+	if f.cancel != nil {
+		f.cancel()
+	}
+	f.url, f.cancel = "", nil
+}
@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"context"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func newTestPrefetcher(fetch PrefetchFunc) *prefetcher {
+	logger := new(logging.MockLogger)
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+	return newPrefetcher(fetch, 0.5, logger)
+}
+
+func TestPrefetcher_StartSameSongOnce(t *testing.T) {
+	calls := make(chan string, 2)
+	f := newTestPrefetcher(func(ctx context.Context, song *voice.Song) error {
+		calls <- song.URL
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	f.start(context.Background(), &voice.Song{URL: "a"})
+	f.start(context.Background(), &voice.Song{URL: "a"})
+	f.stop()
+
+	assert.Equal(t, "a", <-calls)
+	assert.Len(t, calls, 0)
+}
+
+func TestPrefetcher_InvalidateCancelsStaleSong(t *testing.T) {
+	cancelled := make(chan struct{})
+	f := newTestPrefetcher(func(ctx context.Context, song *voice.Song) error {
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	})
+
+	f.start(context.Background(), &voice.Song{URL: "a"})
+	f.invalidate("a")
+	select {
+	case <-cancelled:
+		t.Fatal("la precarga no debería cancelarse si la próxima canción no cambió")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	f.invalidate("b")
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("la precarga debería cancelarse si la próxima canción cambió")
+	}
+}
+
+func TestPrefetcher_StopCancelsInFlightSong(t *testing.T) {
+	cancelled := make(chan struct{})
+	f := newTestPrefetcher(func(ctx context.Context, song *voice.Song) error {
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	})
+
+	f.start(context.Background(), &voice.Song{URL: "a"})
+	f.stop()
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("stop debería cancelar la precarga en curso")
+	}
+}
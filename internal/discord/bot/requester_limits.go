@@ -0,0 +1,88 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"go.uber.org/zap"
+	"time"
+)
+
+// ErrRequesterLimit indica que el solicitante alcanzó su límite de canciones en la lista de reproducción.
+var ErrRequesterLimit = errors.New("se alcanzó el límite de canciones en cola por usuario")
+
+// RequesterLimits define cuánto puede ocupar cada solicitante en la lista de reproducción. Un límite en cero no se aplica.
+type RequesterLimits struct {
+	MaxSongs    int           // Cantidad máxima de canciones en cola por solicitante.
+	MaxDuration time.Duration // Duración total máxima de las canciones en cola por solicitante.
+}
+
+// QueueResult es el resultado de agregar canciones a la lista de reproducción.
+type QueueResult struct {
+	Added    []*voice.Song   // Canciones agregadas.
+	Rejected int             // Canciones descartadas por superar los límites del solicitante.
+	Songs    int             // Canciones en cola del solicitante después de agregar.
+	Duration time.Duration   // Duración total en cola del solicitante después de agregar.
+	Limits   RequesterLimits // Límites aplicados.
+}
+
+// WithRequesterLimits establece los límites de canciones en cola por solicitante.
+func (p *GuildPlayer) WithRequesterLimits(limits RequesterLimits) *GuildPlayer {
+	p.requesterLimits = limits
+	return p
+}
+
+// requesterUsage es lo que ocupa un solicitante en la lista de reproducción.
+type requesterUsage struct {
+	songs    int
+	duration time.Duration
+}
+
+// admitSongs descarta las canciones que superan los límites de su solicitante, en el orden recibido.
+// Las canciones que nadie pidió no tienen límites. Debe llamarse con p.mu tomado.
+func (p *GuildPlayer) admitSongs(songs []*voice.Song) (*QueueResult, error) {
+	result := &QueueResult{Limits: p.requesterLimits}
+	if len(songs) == 0 {
+		return result, nil
+	}
+
+	queued, err := p.songStorage.GetSongs()
+	if err != nil {
+		p.logger.Error("Error al obtener la lista de reproducción", zap.Error(err))
+		return nil, fmt.Errorf("al obtener canciones: %w", err)
+	}
+	usage := make(map[string]*requesterUsage)
+	for _, song := range append(queued, songs...) {
+		if _, ok := usage[song.GetRequesterKey()]; !ok {
+			usage[song.GetRequesterKey()] = &requesterUsage{}
+		}
+	}
+	for _, song := range queued {
+		u := usage[song.GetRequesterKey()]
+		u.songs++
+		u.duration += song.Duration
+	}
+
+	limits := p.requesterLimits
+	for _, song := range songs {
+		key := song.GetRequesterKey()
+		u := usage[key]
+		if key != "" && ((limits.MaxSongs > 0 && u.songs+1 > limits.MaxSongs) ||
+			(limits.MaxDuration > 0 && u.duration+song.Duration > limits.MaxDuration)) {
+			result.Rejected++
+			continue
+		}
+		u.songs++
+		u.duration += song.Duration
+		result.Added = append(result.Added, song)
+	}
+
+	requester := usage[songs[0].GetRequesterKey()]
+	result.Songs = requester.songs
+	result.Duration = requester.duration
+	if len(result.Added) == 0 {
+		p.logger.Info("Canciones descartadas por el límite del solicitante", zap.Int("cantidad", result.Rejected))
+		return result, ErrRequesterLimit
+	}
+	return result, nil
+}
@@ -0,0 +1,78 @@
+package bot
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot/store"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+// queuedSongStorage es un almacenamiento de prueba que solo devuelve las canciones en cola.
+type queuedSongStorage struct {
+	store.SongStorage
+	songs []*voice.Song
+}
+
+func (s *queuedSongStorage) GetSongs() ([]*voice.Song, error) {
+	return s.songs, nil
+}
+
+func newLimitedPlayer(queued []*voice.Song, limits RequesterLimits) *GuildPlayer {
+	logger := new(logging.MockLogger)
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	return (&GuildPlayer{songStorage: &queuedSongStorage{songs: queued}, logger: logger}).WithRequesterLimits(limits)
+}
+
+func TestGuildPlayer_AdmitSongs_MaxSongs(t *testing.T) {
+	p := newLimitedPlayer([]*voice.Song{
+		{RequesterID: "ana"},
+		{RequesterID: "beto"},
+	}, RequesterLimits{MaxSongs: 2})
+
+	result, err := p.admitSongs([]*voice.Song{
+		{Title: "1", RequesterID: "ana"},
+		{Title: "2", RequesterID: "ana"},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Added, 1)
+	assert.Equal(t, "1", result.Added[0].Title)
+	assert.Equal(t, 1, result.Rejected)
+	assert.Equal(t, 2, result.Songs)
+}
+
+func TestGuildPlayer_AdmitSongs_MaxDuration(t *testing.T) {
+	p := newLimitedPlayer(nil, RequesterLimits{MaxDuration: 10 * time.Minute})
+
+	result, err := p.admitSongs([]*voice.Song{
+		{Title: "larga", RequesterID: "ana", Duration: 8 * time.Minute},
+		{Title: "muy larga", RequesterID: "ana", Duration: 5 * time.Minute},
+		{Title: "corta", RequesterID: "ana", Duration: 2 * time.Minute},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Added, 2)
+	assert.Equal(t, "corta", result.Added[1].Title)
+	assert.Equal(t, 10*time.Minute, result.Duration)
+}
+
+func TestGuildPlayer_AdmitSongs_LimitReached(t *testing.T) {
+	p := newLimitedPlayer([]*voice.Song{{RequesterID: "ana"}}, RequesterLimits{MaxSongs: 1})
+
+	result, err := p.admitSongs([]*voice.Song{{RequesterID: "ana"}})
+
+	assert.ErrorIs(t, err, ErrRequesterLimit)
+	assert.Equal(t, 1, result.Rejected)
+}
+
+func TestGuildPlayer_AdmitSongs_WithoutRequester(t *testing.T) {
+	p := newLimitedPlayer([]*voice.Song{{}}, RequesterLimits{MaxSongs: 1})
+
+	result, err := p.admitSongs([]*voice.Song{{}})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Added, 1)
+}
@@ -0,0 +1,41 @@
+package bot
+
+import (
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"go.uber.org/zap"
+)
+
+// defaultMaxConsecutiveFailures es la cantidad de canciones seguidas que pueden fallar antes de abandonar la lista de reproducción.
+const defaultMaxConsecutiveFailures = 3
+
+// WithMaxConsecutiveFailures establece cuántas canciones seguidas pueden fallar antes de abandonar la lista de reproducción.
+// Un valor menor o igual a cero abandona la lista de reproducción con la primera canción que falle.
+func (p *GuildPlayer) WithMaxConsecutiveFailures(max int) *GuildPlayer {
+	p.maxConsecutiveFailures = max
+	return p
+}
+
+// handleSongFailure registra que la canción no se pudo reproducir y lo informa en el canal de texto.
+// Devuelve un error si se alcanzó la cantidad máxima de fallos consecutivos y hay que abandonar la lista de reproducción.
+func (p *GuildPlayer) handleSongFailure(song *voice.Song, textChannel string, cause error, consecutiveFailures int) error {
+	p.logger.Error("No se pudo reproducir la canción", zap.String("título", song.Title), zap.String("URL", song.URL), zap.Int("fallos consecutivos", consecutiveFailures), zap.Error(cause))
+	p.events.Publish(SongFailedEvent{Song: song, Err: cause})
+	p.resetPause()
+	if err := p.stateStorage.SetCurrentSong(nil); err != nil {
+		p.logger.Error("Error al establecer la cancion actual", zap.Error(err))
+	}
+
+	message := fmt.Sprintf("⚠️ No se pudo reproducir **%s**: %v", song.GetHumanName(), cause)
+	if consecutiveFailures >= p.maxConsecutiveFailures {
+		message = fmt.Sprintf("%s\n🛑 Se detuvo la reproducción después de %d canciones fallidas seguidas", message, consecutiveFailures)
+	}
+	if err := p.message.SendMessage(textChannel, message); err != nil {
+		p.logger.Error("Error al informar la canción fallida", zap.Error(err))
+	}
+
+	if consecutiveFailures >= p.maxConsecutiveFailures {
+		return fmt.Errorf("%d canciones fallidas seguidas: %w", consecutiveFailures, cause)
+	}
+	return nil
+}
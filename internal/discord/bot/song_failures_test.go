@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"errors"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot/store"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/discordmessenger"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+// recordingMessageSender es un mensajero de prueba que guarda los mensajes de texto enviados.
+type recordingMessageSender struct {
+	discordmessenger.ChatMessageSender
+	messages []string
+}
+
+func (s *recordingMessageSender) SendMessage(_, message string) error {
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+// idleStateStorage es un almacenamiento de estado de prueba que acepta los cambios sin guardarlos.
+type idleStateStorage struct {
+	store.StateStorage
+}
+
+func (idleStateStorage) GetPaused() (bool, error)               { return false, nil }
+func (idleStateStorage) SetPaused(bool) error                   { return nil }
+func (idleStateStorage) SetCurrentSong(*voice.PlayedSong) error { return nil }
+
+func newFailingPlayer(maxFailures int) (*GuildPlayer, *recordingMessageSender) {
+	logger := new(logging.MockLogger)
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+	messenger := &recordingMessageSender{}
+	p := (&GuildPlayer{
+		stateStorage: idleStateStorage{},
+		message:      messenger,
+		events:       NewEventBus(logger),
+		logger:       logger,
+	}).WithMaxConsecutiveFailures(maxFailures)
+	return p, messenger
+}
+
+func TestGuildPlayer_HandleSongFailure_ContinuesBelowMax(t *testing.T) {
+	p, messenger := newFailingPlayer(3)
+	events, unsubscribe := p.Subscribe(1)
+	defer unsubscribe()
+	song := &voice.Song{Title: "rota"}
+
+	err := p.handleSongFailure(song, "canal", errors.New("video no disponible"), 2)
+
+	assert.NoError(t, err)
+	assert.Len(t, messenger.messages, 1)
+	assert.Contains(t, messenger.messages[0], "video no disponible")
+	assert.NotContains(t, messenger.messages[0], "Se detuvo")
+	assert.Equal(t, SongFailedEvent{Song: song, Err: errors.New("video no disponible")}, <-events)
+}
+
+func TestGuildPlayer_HandleSongFailure_GivesUpAtMax(t *testing.T) {
+	p, messenger := newFailingPlayer(3)
+	cause := errors.New("video no disponible")
+
+	err := p.handleSongFailure(&voice.Song{Title: "rota"}, "canal", cause, 3)
+
+	assert.ErrorIs(t, err, cause)
+	assert.Len(t, messenger.messages, 1)
+	assert.Contains(t, messenger.messages[0], "Se detuvo la reproducción después de 3 canciones fallidas seguidas")
+}
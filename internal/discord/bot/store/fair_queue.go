@@ -0,0 +1,26 @@
+package store
+
+import "github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+
+// FairInsertIndex devuelve el índice en el que se debe insertar la canción para que la lista de reproducción
+// alterne por turnos entre quienes pidieron canciones. La n-ésima canción de cada solicitante queda en la ronda n,
+// después de las canciones de las rondas anteriores y de las ya encoladas en la misma ronda.
+func FairInsertIndex(songs []*voice.Song, song *voice.Song) int {
+	key := song.GetRequesterKey()
+	round := 1
+	for _, queued := range songs {
+		if queued.GetRequesterKey() == key {
+			round++
+		}
+	}
+
+	rounds := make(map[string]int)
+	for i, queued := range songs {
+		queuedKey := queued.GetRequesterKey()
+		rounds[queuedKey]++
+		if rounds[queuedKey] > round {
+			return i
+		}
+	}
+	return len(songs)
+}
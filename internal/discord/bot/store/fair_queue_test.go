@@ -0,0 +1,33 @@
+package store
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFairInsertIndex(t *testing.T) {
+	var songs []*voice.Song
+	add := func(requester, title string) {
+		song := &voice.Song{Title: title, RequesterID: requester}
+		index := FairInsertIndex(songs, song)
+		songs = append(songs[:index], append([]*voice.Song{song}, songs[index:]...)...)
+	}
+
+	add("ana", "a1")
+	add("ana", "a2")
+	add("ana", "a3")
+	add("beto", "b1")
+	add("beto", "b2")
+	add("carla", "c1")
+
+	titles := make([]string, len(songs))
+	for i, song := range songs {
+		titles[i] = song.Title
+	}
+	assert.Equal(t, []string{"a1", "b1", "c1", "a2", "b2", "a3"}, titles)
+}
+
+func TestFairInsertIndex_EmptyPlaylist(t *testing.T) {
+	assert.Equal(t, 0, FairInsertIndex(nil, &voice.Song{RequesterID: "ana"}))
+}
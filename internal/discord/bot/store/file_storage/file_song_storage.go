@@ -0,0 +1,196 @@
+package file_storage
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot/store"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"math/rand"
+)
+
+// FileSongStorage implementa la interfaz SongStorage guardando la lista de reproducción en el archivo del servidor.
+type FileSongStorage struct {
+	file   *guildFile     // file es el archivo del servidor, compartido con FileStateStorage.
+	fair   bool           // fair indica si las canciones agregadas al final se intercalan por turnos entre quienes las pidieron.
+	logger logging.Logger // logger es un registrador para registrar mensajes de depuración y errores.
+}
+
+// WithFairQueue activa o desactiva el modo de cola justa, que intercala por turnos las canciones de cada solicitante.
+func (s *FileSongStorage) WithFairQueue(enabled bool) *FileSongStorage {
+	s.fair = enabled
+	return s
+}
+
+// PrependSong agrega una canción al principio de la lista de reproducción.
+func (s *FileSongStorage) PrependSong(song *voice.Song) error {
+	if err := s.file.update(func(data *guildData) error {
+		data.Songs = append([]*voice.Song{song}, data.Songs...)
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.logger.Info("Canción agregada al principio de la lista de reproducción")
+	return nil
+}
+
+// AppendSong agrega una canción al final de la lista de reproducción.
+// En el modo de cola justa la canción se agrega al final de su turno.
+func (s *FileSongStorage) AppendSong(song *voice.Song) error {
+	if err := s.file.update(func(data *guildData) error {
+		index := len(data.Songs)
+		if s.fair {
+			index = store.FairInsertIndex(data.Songs, song)
+		}
+		data.Songs = append(data.Songs[:index], append([]*voice.Song{song}, data.Songs[index:]...)...)
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.logger.Info("Canción agregada al final de la lista de reproducción")
+	return nil
+}
+
+// AppendSongUnfair agrega una canción al final de la lista de reproducción, aun en el modo de cola justa.
+func (s *FileSongStorage) AppendSongUnfair(song *voice.Song) error {
+	if err := s.file.update(func(data *guildData) error {
+		data.Songs = append(data.Songs, song)
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.logger.Info("Canción agregada al final de la lista de reproducción")
+	return nil
+}
+
+// RemoveSong elimina una canción de la lista de reproducción por posición.
+func (s *FileSongStorage) RemoveSong(position int) (*voice.Song, error) {
+	return s.removeSong(position, nil)
+}
+
+// RemoveRequesterSong elimina la canción de la posición indicada solo si la agregó el usuario indicado.
+func (s *FileSongStorage) RemoveRequesterSong(position int, requesterID string) (*voice.Song, error) {
+	return s.removeSong(position, &requesterID)
+}
+
+// removeSong elimina la canción de la posición indicada. Si requesterID no es nil, solo la elimina si la agregó ese usuario.
+func (s *FileSongStorage) removeSong(position int, requesterID *string) (*voice.Song, error) {
+	index := position - 1
+
+	var song *voice.Song
+	if err := s.file.update(func(data *guildData) error {
+		if index >= len(data.Songs) || index < 0 {
+			return bot.ErrRemoveInvalidPosition
+		}
+		if requesterID != nil && data.Songs[index].RequesterID != *requesterID {
+			return bot.ErrNotSongOwner
+		}
+		song = data.Songs[index]
+		data.Songs = append(data.Songs[:index], data.Songs[index+1:]...)
+		return nil
+	}); err != nil {
+		s.logger.Info("No se pudo eliminar la canción de la lista de reproducción")
+		return nil, err
+	}
+	s.logger.Info("Canción eliminada de la lista de reproducción")
+	return song, nil
+}
+
+// ClearPlaylist elimina todas las canciones de la lista de reproducción.
+func (s *FileSongStorage) ClearPlaylist() error {
+	if err := s.file.update(func(data *guildData) error {
+		data.Songs = make([]*voice.Song, 0)
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.logger.Info("Lista de reproducción borrada")
+	return nil
+}
+
+// GetSongs devuelve todas las canciones de la lista de reproducción.
+func (s *FileSongStorage) GetSongs() ([]*voice.Song, error) {
+	var songs []*voice.Song
+	s.file.read(func(data *guildData) {
+		// Se copian las canciones para evitar modificaciones inadvertidas.
+		songs = make([]*voice.Song, len(data.Songs))
+		copy(songs, data.Songs)
+	})
+	s.logger.Info("Obteniendo todas las canciones de la lista de reproducción")
+	return songs, nil
+}
+
+// PopFirstSong elimina y devuelve la primera canción de la lista de reproducción.
+func (s *FileSongStorage) PopFirstSong() (*voice.Song, error) {
+	var song *voice.Song
+	if err := s.file.update(func(data *guildData) error {
+		if len(data.Songs) == 0 {
+			return bot.ErrNoSongs
+		}
+		song = data.Songs[0]
+		data.Songs = data.Songs[1:]
+		return nil
+	}); err != nil {
+		s.logger.Info("No se pudo obtener la primera canción de la lista de reproducción")
+		return nil, err
+	}
+	s.logger.Info("Primera canción eliminada de la lista de reproducción")
+	return song, nil
+}
+
+// InsertSong inserta una canción en la posición indicada de la lista de reproducción.
+// La posición comienza en 1 y puede ser como máximo la cantidad de canciones más uno.
+func (s *FileSongStorage) InsertSong(position int, song *voice.Song) error {
+	index := position - 1
+
+	if err := s.file.update(func(data *guildData) error {
+		if index > len(data.Songs) || index < 0 {
+			return bot.ErrInvalidPosition
+		}
+		data.Songs = append(data.Songs[:index], append([]*voice.Song{song}, data.Songs[index:]...)...)
+		return nil
+	}); err != nil {
+		s.logger.Info("No se pudo insertar la canción en la lista de reproducción")
+		return err
+	}
+	s.logger.Info("Canción insertada en la lista de reproducción")
+	return nil
+}
+
+// MoveSong mueve una canción de una posición a otra de la lista de reproducción.
+func (s *FileSongStorage) MoveSong(from, to int) (*voice.Song, error) {
+	fromIndex, toIndex := from-1, to-1
+
+	var song *voice.Song
+	if err := s.file.update(func(data *guildData) error {
+		if fromIndex >= len(data.Songs) || fromIndex < 0 || toIndex >= len(data.Songs) || toIndex < 0 {
+			return bot.ErrInvalidPosition
+		}
+		song = data.Songs[fromIndex]
+		if fromIndex < toIndex {
+			copy(data.Songs[fromIndex:toIndex], data.Songs[fromIndex+1:toIndex+1])
+		} else {
+			copy(data.Songs[toIndex+1:fromIndex+1], data.Songs[toIndex:fromIndex])
+		}
+		data.Songs[toIndex] = song
+		return nil
+	}); err != nil {
+		s.logger.Info("No se pudo mover la canción en la lista de reproducción")
+		return nil, err
+	}
+	s.logger.Info("Canción movida en la lista de reproducción")
+	return song, nil
+}
+
+// ShuffleSongs mezcla aleatoriamente las canciones de la lista de reproducción.
+func (s *FileSongStorage) ShuffleSongs() error {
+	if err := s.file.update(func(data *guildData) error {
+		rand.Shuffle(len(data.Songs), func(i, j int) {
+			data.Songs[i], data.Songs[j] = data.Songs[j], data.Songs[i]
+		})
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.logger.Info("Lista de reproducción mezclada")
+	return nil
+}
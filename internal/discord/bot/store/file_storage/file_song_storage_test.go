@@ -0,0 +1,158 @@
+package file_storage
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/Tomas-vilte/GoMusicBot/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestLogger() *logging.MockLogger {
+	mockLogger := new(logging.MockLogger)
+	mockLogger.On("Info", mock.AnythingOfType("string"), mock.AnythingOfType("[]zapcore.Field")).Return()
+	mockLogger.On("Error", mock.AnythingOfType("string"), mock.AnythingOfType("[]zapcore.Field")).Return()
+	return mockLogger
+}
+
+func TestFileSongStorage_PersistsAcrossInstances(t *testing.T) {
+	dir := t.TempDir()
+	songStorage, _, err := NewFileStorage(dir, "guild", newTestLogger())
+	assert.NoError(t, err)
+
+	assert.NoError(t, songStorage.AppendSong(&voice.Song{Title: "1", RequestedBy: utils.String("ana")}))
+	assert.NoError(t, songStorage.AppendSong(&voice.Song{Title: "2"}))
+	assert.NoError(t, songStorage.PrependSong(&voice.Song{Title: "0"}))
+
+	reopened, _, err := NewFileStorage(dir, "guild", newTestLogger())
+	assert.NoError(t, err)
+	songs, err := reopened.GetSongs()
+	assert.NoError(t, err)
+	assert.Len(t, songs, 3)
+	assert.Equal(t, "0", songs[0].Title)
+	assert.Equal(t, "ana", *songs[1].RequestedBy)
+	assert.Equal(t, "2", songs[2].Title)
+}
+
+func TestFileSongStorage_OneFilePerGuild(t *testing.T) {
+	dir := t.TempDir()
+	first, _, err := NewFileStorage(dir, "1", newTestLogger())
+	assert.NoError(t, err)
+	second, _, err := NewFileStorage(dir, "2", newTestLogger())
+	assert.NoError(t, err)
+
+	assert.NoError(t, first.AppendSong(&voice.Song{Title: "1"}))
+
+	songs, err := second.GetSongs()
+	assert.NoError(t, err)
+	assert.Empty(t, songs)
+	assert.FileExists(t, filepath.Join(dir, "1.json"))
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestFileSongStorage_RemoveSong(t *testing.T) {
+	songStorage, _, err := NewFileStorage(t.TempDir(), "guild", newTestLogger())
+	assert.NoError(t, err)
+	assert.NoError(t, songStorage.AppendSong(&voice.Song{Title: "1"}))
+	assert.NoError(t, songStorage.AppendSong(&voice.Song{Title: "2"}))
+
+	song, err := songStorage.RemoveSong(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", song.Title)
+
+	_, err = songStorage.RemoveSong(5)
+	assert.ErrorIs(t, err, bot.ErrRemoveInvalidPosition)
+
+	songs, _ := songStorage.GetSongs()
+	assert.Len(t, songs, 1)
+	assert.Equal(t, "2", songs[0].Title)
+}
+
+func TestFileSongStorage_RemoveRequesterSong(t *testing.T) {
+	songStorage, _, err := NewFileStorage(t.TempDir(), "guild", newTestLogger())
+	assert.NoError(t, err)
+	assert.NoError(t, songStorage.AppendSong(&voice.Song{Title: "1", RequesterID: "ana"}))
+	assert.NoError(t, songStorage.AppendSong(&voice.Song{Title: "2", RequesterID: "beto"}))
+
+	_, err = songStorage.RemoveRequesterSong(1, "beto")
+	assert.ErrorIs(t, err, bot.ErrNotSongOwner)
+	_, err = songStorage.RemoveRequesterSong(3, "beto")
+	assert.ErrorIs(t, err, bot.ErrRemoveInvalidPosition)
+
+	song, err := songStorage.RemoveRequesterSong(2, "beto")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", song.Title)
+
+	songs, _ := songStorage.GetSongs()
+	assert.Len(t, songs, 1)
+	assert.Equal(t, "1", songs[0].Title)
+}
+
+func TestFileSongStorage_PopFirstSong(t *testing.T) {
+	songStorage, _, err := NewFileStorage(t.TempDir(), "guild", newTestLogger())
+	assert.NoError(t, err)
+
+	_, err = songStorage.PopFirstSong()
+	assert.ErrorIs(t, err, bot.ErrNoSongs)
+
+	assert.NoError(t, songStorage.AppendSong(&voice.Song{Title: "1"}))
+	song, err := songStorage.PopFirstSong()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", song.Title)
+}
+
+func TestFileSongStorage_InsertAndMoveSong(t *testing.T) {
+	songStorage, _, err := NewFileStorage(t.TempDir(), "guild", newTestLogger())
+	assert.NoError(t, err)
+	assert.NoError(t, songStorage.AppendSong(&voice.Song{Title: "a"}))
+	assert.NoError(t, songStorage.AppendSong(&voice.Song{Title: "c"}))
+
+	assert.NoError(t, songStorage.InsertSong(2, &voice.Song{Title: "b"}))
+	assert.ErrorIs(t, songStorage.InsertSong(5, &voice.Song{}), bot.ErrInvalidPosition)
+
+	song, err := songStorage.MoveSong(3, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "c", song.Title)
+
+	songs, _ := songStorage.GetSongs()
+	assert.Equal(t, []string{"c", "a", "b"}, []string{songs[0].Title, songs[1].Title, songs[2].Title})
+}
+
+func TestFileSongStorage_FairQueue(t *testing.T) {
+	songStorage, _, err := NewFileStorage(t.TempDir(), "guild", newTestLogger())
+	assert.NoError(t, err)
+	songStorage.WithFairQueue(true)
+
+	assert.NoError(t, songStorage.AppendSong(&voice.Song{Title: "a1", RequesterID: "ana"}))
+	assert.NoError(t, songStorage.AppendSong(&voice.Song{Title: "a2", RequesterID: "ana"}))
+	assert.NoError(t, songStorage.AppendSong(&voice.Song{Title: "b1", RequesterID: "beto"}))
+
+	songs, _ := songStorage.GetSongs()
+	assert.Equal(t, []string{"a1", "b1", "a2"}, []string{songs[0].Title, songs[1].Title, songs[2].Title})
+
+	// AppendSongUnfair agrega al final aunque a beto le toque un turno antes.
+	assert.NoError(t, songStorage.AppendSongUnfair(&voice.Song{Title: "b2", RequesterID: "beto"}))
+	assert.NoError(t, songStorage.AppendSong(&voice.Song{Title: "c1", RequesterID: "carla"}))
+	songs, _ = songStorage.GetSongs()
+	assert.Len(t, songs, 5)
+	assert.Equal(t, "b2", songs[4].Title)
+}
+
+func TestFileSongStorage_CorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "guild.json"), []byte("{no es json"), 0o644))
+
+	songStorage, stateStorage, err := NewFileStorage(dir, "guild", newTestLogger())
+	assert.NoError(t, err)
+
+	songs, _ := songStorage.GetSongs()
+	assert.Empty(t, songs)
+	volume, _ := stateStorage.GetVolume()
+	assert.Equal(t, voice.DefaultVolume, volume)
+}
@@ -0,0 +1,191 @@
+package file_storage
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"sync"
+	"time"
+)
+
+// positionSaveInterval es cada cuánto se guarda en disco la posición de la canción actual. El reproductor la
+// actualiza cada segundo; entre guardados la posición solo cambia en memoria y se guarda con el próximo cambio.
+const positionSaveInterval = 15 * time.Second
+
+// FileStateStorage implementa la interfaz StateStorage guardando el estado del reproductor en el archivo del servidor.
+type FileStateStorage struct {
+	file            *guildFile     // file es el archivo del servidor, compartido con FileSongStorage.
+	logger          logging.Logger // logger es un registrador para registrar mensajes de depuración y errores.
+	positionMutex   sync.Mutex     // positionMutex protege positionSavedAt y serializa los cambios de la canción actual.
+	positionSavedAt time.Time      // positionSavedAt es cuándo se guardó en disco la canción actual por última vez.
+}
+
+// GetCurrentSong devuelve una copia de la canción actual que se está reproduciendo.
+func (s *FileStateStorage) GetCurrentSong() (*voice.PlayedSong, error) {
+	var value *voice.PlayedSong
+	s.file.read(func(data *guildData) {
+		if data.CurrentSong != nil {
+			current := *data.CurrentSong
+			value = &current
+		}
+	})
+	return value, nil
+}
+
+// SetCurrentSong establece la canción actual que se está reproduciendo. Los cambios de canción se guardan en disco
+// enseguida, pero los avances de posición de la misma canción se guardan como mucho cada positionSaveInterval.
+func (s *FileStateStorage) SetCurrentSong(song *voice.PlayedSong) error {
+	if song != nil {
+		current := *song
+		song = &current
+	}
+
+	s.positionMutex.Lock()
+	defer s.positionMutex.Unlock()
+
+	var progress bool
+	s.file.read(func(data *guildData) {
+		progress = song != nil && data.CurrentSong != nil &&
+			data.CurrentSong.URL == song.URL && data.CurrentSong.StartPosition == song.StartPosition
+	})
+	if progress && time.Since(s.positionSavedAt) < positionSaveInterval {
+		s.file.set(func(data *guildData) {
+			data.CurrentSong = song
+		})
+		return nil
+	}
+
+	if err := s.file.update(func(data *guildData) error {
+		data.CurrentSong = song
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.positionSavedAt = time.Now()
+	return nil
+}
+
+// GetVoiceChannel devuelve el ID del canal de voz.
+func (s *FileStateStorage) GetVoiceChannel() (string, error) {
+	var value string
+	s.file.read(func(data *guildData) {
+		value = data.VoiceChannel
+	})
+	return value, nil
+}
+
+// SetVoiceChannel establece el ID del canal de voz.
+func (s *FileStateStorage) SetVoiceChannel(channelID string) error {
+	if err := s.file.update(func(data *guildData) error {
+		data.VoiceChannel = channelID
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.logger.Info("Canal de voz establecido")
+	return nil
+}
+
+// GetTextChannel devuelve el ID del canal de texto.
+func (s *FileStateStorage) GetTextChannel() (string, error) {
+	var value string
+	s.file.read(func(data *guildData) {
+		value = data.TextChannel
+	})
+	return value, nil
+}
+
+// SetTextChannel establece el ID del canal de texto.
+func (s *FileStateStorage) SetTextChannel(channelID string) error {
+	if err := s.file.update(func(data *guildData) error {
+		data.TextChannel = channelID
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.logger.Info("Canal de texto establecido")
+	return nil
+}
+
+// GetPaused indica si la reproducción actual está en pausa.
+func (s *FileStateStorage) GetPaused() (bool, error) {
+	var value bool
+	s.file.read(func(data *guildData) {
+		value = data.Paused
+	})
+	return value, nil
+}
+
+// SetPaused establece si la reproducción actual está en pausa.
+func (s *FileStateStorage) SetPaused(paused bool) error {
+	if err := s.file.update(func(data *guildData) error {
+		data.Paused = paused
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.logger.Info("Estado de pausa establecido")
+	return nil
+}
+
+// GetLoopMode devuelve el modo de repetición del reproductor.
+func (s *FileStateStorage) GetLoopMode() (voice.LoopMode, error) {
+	var value voice.LoopMode
+	s.file.read(func(data *guildData) {
+		value = data.LoopMode
+	})
+	return value, nil
+}
+
+// SetLoopMode establece el modo de repetición del reproductor.
+func (s *FileStateStorage) SetLoopMode(mode voice.LoopMode) error {
+	if err := s.file.update(func(data *guildData) error {
+		data.LoopMode = mode
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.logger.Info("Modo de repetición establecido")
+	return nil
+}
+
+// GetVolume devuelve el volumen del reproductor en porcentaje.
+func (s *FileStateStorage) GetVolume() (int, error) {
+	var value int
+	s.file.read(func(data *guildData) {
+		value = data.Volume
+	})
+	return value, nil
+}
+
+// SetVolume establece el volumen del reproductor en porcentaje.
+func (s *FileStateStorage) SetVolume(volume int) error {
+	if err := s.file.update(func(data *guildData) error {
+		data.Volume = volume
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.logger.Info("Volumen establecido")
+	return nil
+}
+
+// GetAutoplay indica si la reproducción automática está activada.
+func (s *FileStateStorage) GetAutoplay() (bool, error) {
+	var value bool
+	s.file.read(func(data *guildData) {
+		value = data.Autoplay
+	})
+	return value, nil
+}
+
+// SetAutoplay activa o desactiva la reproducción automática.
+func (s *FileStateStorage) SetAutoplay(enabled bool) error {
+	if err := s.file.update(func(data *guildData) error {
+		data.Autoplay = enabled
+		return nil
+	}); err != nil {
+		return err
+	}
+	s.logger.Info("Reproducción automática establecida")
+	return nil
+}
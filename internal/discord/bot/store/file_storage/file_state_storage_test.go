@@ -0,0 +1,142 @@
+package file_storage
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFileStateStorage_Defaults(t *testing.T) {
+	_, stateStorage, err := NewFileStorage(t.TempDir(), "guild", newTestLogger())
+	assert.NoError(t, err)
+
+	currentSong, _ := stateStorage.GetCurrentSong()
+	loopMode, _ := stateStorage.GetLoopMode()
+	volume, _ := stateStorage.GetVolume()
+
+	assert.Nil(t, currentSong)
+	assert.Equal(t, voice.LoopModeOff, loopMode)
+	assert.Equal(t, voice.DefaultVolume, volume)
+}
+
+func TestFileStateStorage_PersistsAcrossInstances(t *testing.T) {
+	dir := t.TempDir()
+	_, stateStorage, err := NewFileStorage(dir, "guild", newTestLogger())
+	assert.NoError(t, err)
+
+	assert.NoError(t, stateStorage.SetCurrentSong(&voice.PlayedSong{Song: voice.Song{Title: "actual", Duration: time.Minute}, Position: 30 * time.Second}))
+	assert.NoError(t, stateStorage.SetVoiceChannel("voz"))
+	assert.NoError(t, stateStorage.SetTextChannel("texto"))
+	assert.NoError(t, stateStorage.SetPaused(true))
+	assert.NoError(t, stateStorage.SetLoopMode(voice.LoopModeQueue))
+	assert.NoError(t, stateStorage.SetVolume(150))
+	assert.NoError(t, stateStorage.SetAutoplay(true))
+
+	_, reopened, err := NewFileStorage(dir, "guild", newTestLogger())
+	assert.NoError(t, err)
+
+	currentSong, _ := reopened.GetCurrentSong()
+	voiceChannel, _ := reopened.GetVoiceChannel()
+	textChannel, _ := reopened.GetTextChannel()
+	paused, _ := reopened.GetPaused()
+	loopMode, _ := reopened.GetLoopMode()
+	volume, _ := reopened.GetVolume()
+	autoplay, _ := reopened.GetAutoplay()
+
+	assert.Equal(t, "actual", currentSong.Title)
+	assert.Equal(t, 30*time.Second, currentSong.Position)
+	assert.Equal(t, "voz", voiceChannel)
+	assert.Equal(t, "texto", textChannel)
+	assert.True(t, paused)
+	assert.Equal(t, voice.LoopModeQueue, loopMode)
+	assert.Equal(t, 150, volume)
+	assert.True(t, autoplay)
+}
+
+func TestFileStateStorage_SharesFileWithSongStorage(t *testing.T) {
+	dir := t.TempDir()
+	songStorage, stateStorage, err := NewFileStorage(dir, "guild", newTestLogger())
+	assert.NoError(t, err)
+
+	assert.NoError(t, songStorage.AppendSong(&voice.Song{Title: "1"}))
+	assert.NoError(t, stateStorage.SetVolume(50))
+
+	reopenedSongs, reopenedState, err := NewFileStorage(dir, "guild", newTestLogger())
+	assert.NoError(t, err)
+	songs, _ := reopenedSongs.GetSongs()
+	volume, _ := reopenedState.GetVolume()
+	assert.Len(t, songs, 1)
+	assert.Equal(t, 50, volume)
+}
+
+func TestFileStateStorage_ThrottlesPositionUpdates(t *testing.T) {
+	dir := t.TempDir()
+	_, stateStorage, err := NewFileStorage(dir, "guild", newTestLogger())
+	assert.NoError(t, err)
+	song := voice.Song{Title: "actual", URL: "actual", Duration: time.Minute}
+
+	reopenedPosition := func() *voice.PlayedSong {
+		_, reopened, err := NewFileStorage(dir, "guild", newTestLogger())
+		assert.NoError(t, err)
+		currentSong, _ := reopened.GetCurrentSong()
+		return currentSong
+	}
+
+	// El comienzo de la canción se guarda enseguida.
+	assert.NoError(t, stateStorage.SetCurrentSong(&voice.PlayedSong{Song: song}))
+	assert.Equal(t, time.Duration(0), reopenedPosition().Position)
+
+	// Los avances de posición quedan en memoria hasta el próximo guardado.
+	assert.NoError(t, stateStorage.SetCurrentSong(&voice.PlayedSong{Song: song, Position: time.Second}))
+	currentSong, _ := stateStorage.GetCurrentSong()
+	assert.Equal(t, time.Second, currentSong.Position)
+	assert.Equal(t, time.Duration(0), reopenedPosition().Position)
+
+	// Cualquier otro cambio guarda también la posición.
+	assert.NoError(t, stateStorage.SetVolume(150))
+	assert.Equal(t, time.Second, reopenedPosition().Position)
+
+	// El fin de la canción se guarda enseguida.
+	assert.NoError(t, stateStorage.SetCurrentSong(nil))
+	assert.Nil(t, reopenedPosition())
+}
+
+func TestFileStateStorage_GetCurrentSongReturnsCopy(t *testing.T) {
+	_, stateStorage, err := NewFileStorage(t.TempDir(), "guild", newTestLogger())
+	assert.NoError(t, err)
+
+	assert.NoError(t, stateStorage.SetCurrentSong(&voice.PlayedSong{Song: voice.Song{Title: "actual"}}))
+	currentSong, _ := stateStorage.GetCurrentSong()
+	currentSong.Position = time.Minute
+
+	currentSong, _ = stateStorage.GetCurrentSong()
+	assert.Equal(t, time.Duration(0), currentSong.Position)
+}
+
+func TestFileStateStorage_RejectedChangeKeepsUnsavedPosition(t *testing.T) {
+	dir := t.TempDir()
+	songStorage, stateStorage, err := NewFileStorage(dir, "guild", newTestLogger())
+	assert.NoError(t, err)
+	song := voice.Song{Title: "actual", URL: "actual", Duration: time.Minute}
+	assert.NoError(t, songStorage.AppendSong(&voice.Song{Title: "ajena", RequesterID: "ana"}))
+	assert.NoError(t, stateStorage.SetCurrentSong(&voice.PlayedSong{Song: song}))
+	assert.NoError(t, stateStorage.SetCurrentSong(&voice.PlayedSong{Song: song, Position: time.Second}))
+
+	// Un cambio rechazado no descarta el avance de posición que todavía no se guardó.
+	_, err = songStorage.RemoveRequesterSong(1, "beto")
+	assert.ErrorIs(t, err, bot.ErrNotSongOwner)
+	_, err = songStorage.MoveSong(1, 5)
+	assert.ErrorIs(t, err, bot.ErrInvalidPosition)
+
+	currentSong, _ := stateStorage.GetCurrentSong()
+	assert.Equal(t, time.Second, currentSong.Position)
+
+	// El próximo guardado incluye la posición.
+	assert.NoError(t, stateStorage.SetVolume(150))
+	_, reopened, err := NewFileStorage(dir, "guild", newTestLogger())
+	assert.NoError(t, err)
+	currentSong, _ = reopened.GetCurrentSong()
+	assert.Equal(t, time.Second, currentSong.Position)
+}
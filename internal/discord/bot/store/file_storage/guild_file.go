@@ -0,0 +1,153 @@
+package file_storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// guildData es el contenido del archivo de un servidor: la lista de reproducción y el estado del reproductor.
+type guildData struct {
+	Songs        []*voice.Song     `json:"songs"`
+	CurrentSong  *voice.PlayedSong `json:"current_song,omitempty"`
+	TextChannel  string            `json:"text_channel"`
+	VoiceChannel string            `json:"voice_channel"`
+	Paused       bool              `json:"paused"`
+	LoopMode     voice.LoopMode    `json:"loop_mode"`
+	Volume       int               `json:"volume"`
+	Autoplay     bool              `json:"autoplay"`
+}
+
+// guildFile mantiene en memoria el contenido del archivo de un servidor y lo guarda en disco después de cada cambio.
+type guildFile struct {
+	mutex  sync.RWMutex   // mutex protege data.
+	path   string         // path es la ruta del archivo del servidor.
+	data   guildData      // data es el contenido actual del archivo, incluidos los cambios de set todavía sin guardar.
+	logger logging.Logger // logger es un registrador para registrar mensajes de depuración y errores.
+}
+
+// openGuildFile abre el archivo del servidor dentro de dir, creando el directorio si no existe.
+// Si el archivo no existe o está dañado, se empieza con una lista de reproducción vacía.
+func openGuildFile(dir, guildID string, logger logging.Logger) (*guildFile, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("al crear el directorio del store: %w", err)
+	}
+
+	f := &guildFile{
+		path:   filepath.Join(dir, filepath.Base(guildID)+".json"),
+		data:   defaultGuildData(),
+		logger: logger,
+	}
+
+	content, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("al leer el archivo del servidor: %w", err)
+	}
+	if err := json.Unmarshal(content, &f.data); err != nil {
+		logger.Error("El archivo del servidor está dañado, se empieza con una lista de reproducción vacía", zap.String("archivo", f.path), zap.Error(err))
+		f.data = defaultGuildData()
+	}
+	return f, nil
+}
+
+func defaultGuildData() guildData {
+	return guildData{
+		Songs:    make([]*voice.Song, 0),
+		LoopMode: voice.LoopModeOff,
+		Volume:   voice.DefaultVolume,
+	}
+}
+
+// read ejecuta fn con el contenido actual del archivo para leerlo.
+func (f *guildFile) read(fn func(data *guildData)) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	fn(&f.data)
+}
+
+// set aplica fn al contenido del archivo sin guardarlo en disco. El cambio se guarda con el próximo update
+// que se pueda guardar.
+func (f *guildFile) set(fn func(data *guildData)) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	fn(&f.data)
+}
+
+// update aplica fn al contenido del archivo y lo guarda en disco. Si fn devuelve un error o no se puede guardar,
+// se deshacen solo los cambios de fn: los de set que todavía no se guardaron se conservan.
+func (f *guildFile) update(fn func(data *guildData) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	before, err := json.Marshal(f.data)
+	if err != nil {
+		return fmt.Errorf("al codificar el archivo del servidor: %w", err)
+	}
+
+	if err := fn(&f.data); err != nil {
+		f.restore(before)
+		return err
+	}
+
+	content, err := json.Marshal(f.data)
+	if err != nil {
+		f.restore(before)
+		return fmt.Errorf("al codificar el archivo del servidor: %w", err)
+	}
+	if err := writeFileAtomic(f.path, content); err != nil {
+		f.logger.Error("Error al guardar el archivo del servidor", zap.String("archivo", f.path), zap.Error(err))
+		f.restore(before)
+		return fmt.Errorf("al guardar el archivo del servidor: %w", err)
+	}
+	return nil
+}
+
+// restore vuelve al contenido codificado en content, tomado antes de un cambio. Debe llamarse con f.mutex tomado.
+func (f *guildFile) restore(content []byte) {
+	data := defaultGuildData()
+	if err := json.Unmarshal(content, &data); err != nil {
+		f.logger.Error("Error al deshacer los cambios del archivo del servidor", zap.Error(err))
+	}
+	f.data = data
+}
+
+// writeFileAtomic escribe el contenido en un archivo temporal del mismo directorio y lo renombra,
+// para que una caída a mitad de la escritura nunca deje el archivo incompleto.
+func writeFileAtomic(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// NewFileStorage crea el almacenamiento de canciones y de estado de un servidor, guardados en un único archivo dentro de dir.
+func NewFileStorage(dir, guildID string, logger logging.Logger) (*FileSongStorage, *FileStateStorage, error) {
+	f, err := openGuildFile(dir, guildID, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &FileSongStorage{file: f, logger: logger}, &FileStateStorage{file: f, logger: logger}, nil
+}
@@ -0,0 +1,13 @@
+package store
+
+import "github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+
+// HistoryStorage define métodos para el almacenamiento del historial de canciones reproducidas.
+type HistoryStorage interface {
+	// AddSong agrega una canción al historial como la más reciente.
+	AddSong(*voice.Song) error
+	// GetSongs devuelve las canciones del historial, de la más reciente a la más antigua.
+	GetSongs() ([]*voice.Song, error)
+	// PopLastSong quita y devuelve la canción reproducida más recientemente.
+	PopLastSong() (*voice.Song, error)
+}
@@ -0,0 +1,72 @@
+package inmemory_storage
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"sync"
+)
+
+// InmemoryHistoryStorage implementa la interfaz HistoryStorage utilizando la memoria RAM para almacenar el historial de canciones.
+type InmemoryHistoryStorage struct {
+	mutex   sync.RWMutex   // mutex se utiliza para garantizar la concurrencia segura al manipular el historial.
+	songs   []*voice.Song  // songs es el historial de canciones, de la más antigua a la más reciente.
+	maxSize int            // maxSize es la cantidad máxima de canciones que se guardan en el historial.
+	logger  logging.Logger // logger es un registrador para registrar mensajes de depuración y errores.
+}
+
+// NewInmemoryHistoryStorage crea una nueva instancia de InmemoryHistoryStorage que guarda como máximo maxSize canciones.
+func NewInmemoryHistoryStorage(logger logging.Logger, maxSize int) *InmemoryHistoryStorage {
+	return &InmemoryHistoryStorage{
+		mutex:   sync.RWMutex{},
+		songs:   make([]*voice.Song, 0, maxSize),
+		maxSize: maxSize,
+		logger:  logger,
+	}
+}
+
+// AddSong agrega una canción al historial, descartando la más antigua si se supera el tamaño máximo.
+func (s *InmemoryHistoryStorage) AddSong(song *voice.Song) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.maxSize <= 0 {
+		return nil
+	}
+
+	s.songs = append(s.songs, song)
+	if len(s.songs) > s.maxSize {
+		copy(s.songs, s.songs[len(s.songs)-s.maxSize:])
+		s.songs = s.songs[:s.maxSize]
+	}
+	s.logger.Info("Canción agregada al historial")
+	return nil
+}
+
+// GetSongs devuelve las canciones del historial, de la más reciente a la más antigua.
+func (s *InmemoryHistoryStorage) GetSongs() ([]*voice.Song, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	songs := make([]*voice.Song, len(s.songs))
+	for i, song := range s.songs {
+		songs[len(s.songs)-1-i] = song
+	}
+	return songs, nil
+}
+
+// PopLastSong quita y devuelve la canción reproducida más recientemente.
+func (s *InmemoryHistoryStorage) PopLastSong() (*voice.Song, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.songs) == 0 {
+		return nil, bot.ErrEmptyHistory
+	}
+
+	song := s.songs[len(s.songs)-1]
+	s.songs[len(s.songs)-1] = nil
+	s.songs = s.songs[:len(s.songs)-1]
+	s.logger.Info("Canción quitada del historial")
+	return song, nil
+}
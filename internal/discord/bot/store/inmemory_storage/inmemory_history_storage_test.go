@@ -0,0 +1,47 @@
+package inmemory_storage
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestInmemoryHistoryStorage_AddSong(t *testing.T) {
+	mockLogger := new(logging.MockLogger)
+	mockLogger.On("Info", "Canción agregada al historial", mock.AnythingOfType("[]zapcore.Field")).Return()
+
+	storage := NewInmemoryHistoryStorage(mockLogger, 2)
+
+	assert.NoError(t, storage.AddSong(&voice.Song{Title: "1"}))
+	assert.NoError(t, storage.AddSong(&voice.Song{Title: "2"}))
+	assert.NoError(t, storage.AddSong(&voice.Song{Title: "3"}))
+
+	songs, err := storage.GetSongs()
+	assert.NoError(t, err)
+	assert.Equal(t, []*voice.Song{{Title: "3"}, {Title: "2"}}, songs)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestInmemoryHistoryStorage_PopLastSong(t *testing.T) {
+	mockLogger := new(logging.MockLogger)
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+	storage := NewInmemoryHistoryStorage(mockLogger, 10)
+
+	_, err := storage.PopLastSong()
+	assert.ErrorIs(t, err, bot.ErrEmptyHistory)
+
+	assert.NoError(t, storage.AddSong(&voice.Song{Title: "1"}))
+	assert.NoError(t, storage.AddSong(&voice.Song{Title: "2"}))
+
+	song, err := storage.PopLastSong()
+	assert.NoError(t, err)
+	assert.Equal(t, "2", song.Title)
+
+	songs, err := storage.GetSongs()
+	assert.NoError(t, err)
+	assert.Equal(t, []*voice.Song{{Title: "1"}}, songs)
+}
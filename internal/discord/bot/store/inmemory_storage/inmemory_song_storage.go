@@ -2,8 +2,10 @@ package inmemory_storage
 
 import (
 	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot/store"
 	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
 	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"math/rand"
 	"sync"
 )
 
@@ -12,6 +14,7 @@ type InmemorySongStorage struct {
 	mutex  sync.RWMutex   // mutex se utiliza para garantizar la concurrencia segura al manipular la lista de reproducción.
 	songs  []*voice.Song  // songs es la lista de reproducción de canciones almacenada en memoria.
 	logger logging.Logger // logger es un registrador para registrar mensajes de depuración y errores.
+	fair   bool           // fair indica si las canciones agregadas al final se intercalan por turnos entre quienes las pidieron.
 }
 
 // NewInmemorySongStorage crea una nueva instancia de InmemorySongStorage.
@@ -23,6 +26,12 @@ func NewInmemorySongStorage(logger logging.Logger) *InmemorySongStorage {
 	}
 }
 
+// WithFairQueue activa o desactiva el modo de cola justa, que intercala por turnos las canciones de cada solicitante.
+func (s *InmemorySongStorage) WithFairQueue(enabled bool) *InmemorySongStorage {
+	s.fair = enabled
+	return s
+}
+
 // PrependSong agrega una canción al principio de la lista de reproducción.
 func (s *InmemorySongStorage) PrependSong(song *voice.Song) error {
 	s.mutex.Lock()
@@ -34,10 +43,27 @@ func (s *InmemorySongStorage) PrependSong(song *voice.Song) error {
 }
 
 // AppendSong agrega una canción al final de la lista de reproducción.
+// En el modo de cola justa la canción se agrega al final de su turno.
 func (s *InmemorySongStorage) AppendSong(song *voice.Song) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	if s.fair {
+		index := store.FairInsertIndex(s.songs, song)
+		s.songs = append(s.songs[:index], append([]*voice.Song{song}, s.songs[index:]...)...)
+		s.logger.Info("Canción agregada a la lista de reproducción en su turno")
+		return nil
+	}
+	s.songs = append(s.songs, song)
+	s.logger.Info("Canción agregada al final de la lista de reproducción")
+	return nil
+}
+
+// AppendSongUnfair agrega una canción al final de la lista de reproducción, aun en el modo de cola justa.
+func (s *InmemorySongStorage) AppendSongUnfair(song *voice.Song) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
 	s.songs = append(s.songs, song)
 	s.logger.Info("Canción agregada al final de la lista de reproducción")
 	return nil
@@ -45,6 +71,16 @@ func (s *InmemorySongStorage) AppendSong(song *voice.Song) error {
 
 // RemoveSong elimina una canción de la lista de reproducción por posición.
 func (s *InmemorySongStorage) RemoveSong(position int) (*voice.Song, error) {
+	return s.removeSong(position, nil)
+}
+
+// RemoveRequesterSong elimina la canción de la posición indicada solo si la agregó el usuario indicado.
+func (s *InmemorySongStorage) RemoveRequesterSong(position int, requesterID string) (*voice.Song, error) {
+	return s.removeSong(position, &requesterID)
+}
+
+// removeSong elimina la canción de la posición indicada. Si requesterID no es nil, solo la elimina si la agregó ese usuario.
+func (s *InmemorySongStorage) removeSong(position int, requesterID *string) (*voice.Song, error) {
 	index := position - 1
 
 	s.mutex.Lock()
@@ -56,6 +92,10 @@ func (s *InmemorySongStorage) RemoveSong(position int) (*voice.Song, error) {
 	}
 
 	song := s.songs[index]
+	if requesterID != nil && song.RequesterID != *requesterID {
+		s.logger.Info("La canción a eliminar es de otro usuario")
+		return nil, bot.ErrNotSongOwner
+	}
 
 	copy(s.songs[index:], s.songs[index+1:])
 	s.songs[len(s.songs)-1] = nil
@@ -102,3 +142,58 @@ func (s *InmemorySongStorage) PopFirstSong() (*voice.Song, error) {
 	s.logger.Info("Primera canción eliminada de la lista de reproducción")
 	return song, nil
 }
+
+// InsertSong inserta una canción en la posición indicada de la lista de reproducción.
+// La posición comienza en 1 y puede ser como máximo la cantidad de canciones más uno.
+func (s *InmemorySongStorage) InsertSong(position int, song *voice.Song) error {
+	index := position - 1
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if index > len(s.songs) || index < 0 {
+		s.logger.Info("Posición de inserción de canción inválida")
+		return bot.ErrInvalidPosition
+	}
+
+	s.songs = append(s.songs, nil)
+	copy(s.songs[index+1:], s.songs[index:])
+	s.songs[index] = song
+	s.logger.Info("Canción insertada en la lista de reproducción")
+	return nil
+}
+
+// MoveSong mueve una canción de una posición a otra de la lista de reproducción.
+func (s *InmemorySongStorage) MoveSong(from, to int) (*voice.Song, error) {
+	fromIndex, toIndex := from-1, to-1
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if fromIndex >= len(s.songs) || fromIndex < 0 || toIndex >= len(s.songs) || toIndex < 0 {
+		s.logger.Info("Posición para mover canción inválida")
+		return nil, bot.ErrInvalidPosition
+	}
+
+	song := s.songs[fromIndex]
+	if fromIndex < toIndex {
+		copy(s.songs[fromIndex:toIndex], s.songs[fromIndex+1:toIndex+1])
+	} else {
+		copy(s.songs[toIndex+1:fromIndex+1], s.songs[toIndex:fromIndex])
+	}
+	s.songs[toIndex] = song
+	s.logger.Info("Canción movida en la lista de reproducción")
+	return song, nil
+}
+
+// ShuffleSongs mezcla aleatoriamente las canciones de la lista de reproducción.
+func (s *InmemorySongStorage) ShuffleSongs() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	rand.Shuffle(len(s.songs), func(i, j int) {
+		s.songs[i], s.songs[j] = s.songs[j], s.songs[i]
+	})
+	s.logger.Info("Lista de reproducción mezclada")
+	return nil
+}
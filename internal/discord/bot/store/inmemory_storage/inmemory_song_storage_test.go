@@ -84,6 +84,29 @@ func TestInmemorySongStorage_RemoveSong(t *testing.T) {
 	mockLogger.AssertExpectations(t)
 }
 
+func TestInmemorySongStorage_RemoveRequesterSong(t *testing.T) {
+	mockLogger := new(logging.MockLogger)
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+	storage := NewInmemorySongStorage(mockLogger)
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "1", RequesterID: "ana"}))
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "2", RequesterID: "beto"}))
+
+	_, err := storage.RemoveRequesterSong(1, "beto")
+	assert.ErrorIs(t, err, bot.ErrNotSongOwner)
+	_, err = storage.RemoveRequesterSong(3, "beto")
+	assert.ErrorIs(t, err, bot.ErrRemoveInvalidPosition)
+
+	song, err := storage.RemoveRequesterSong(2, "beto")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", song.Title)
+
+	songs, err := storage.GetSongs()
+	assert.NoError(t, err)
+	assert.Len(t, songs, 1)
+	assert.Equal(t, "1", songs[0].Title)
+}
+
 func TestInmemorySongStorage_GetSongs(t *testing.T) {
 	mockLogger := new(logging.MockLogger)
 	mockLogger.On("Info", "Canción agregada al final de la lista de reproducción", mock.Anything).Return()
@@ -184,3 +207,160 @@ func TestInmemorySongStorage_ClearPlaylist(t *testing.T) {
 
 	mockLogger.AssertExpectations(t)
 }
+
+func TestInmemorySongStorage_InsertSong(t *testing.T) {
+	mockLogger := new(logging.MockLogger)
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+	storage := NewInmemorySongStorage(mockLogger)
+
+	song1 := &voice.Song{Title: "1"}
+	song2 := &voice.Song{Title: "2"}
+	song3 := &voice.Song{Title: "3"}
+
+	if err := storage.AppendSong(song1); err != nil {
+		t.Errorf("Error al agregar canción: %v", err)
+	}
+	if err := storage.AppendSong(song3); err != nil {
+		t.Errorf("Error al agregar canción: %v", err)
+	}
+
+	// Test case: Insertar canción en posición intermedia
+	if err := storage.InsertSong(2, song2); err != nil {
+		t.Errorf("Error al insertar canción: %v", err)
+	}
+
+	songs, _ := storage.GetSongs()
+	expectedSongs := []*voice.Song{song1, song2, song3}
+	if !reflect.DeepEqual(songs, expectedSongs) {
+		t.Errorf("Canciones obtenidas incorrectas. Esperado: %v, Obtenido: %v", expectedSongs, songs)
+	}
+
+	// Test case: Insertar canción al final de la lista
+	song4 := &voice.Song{Title: "4"}
+	if err := storage.InsertSong(4, song4); err != nil {
+		t.Errorf("Error al insertar canción: %v", err)
+	}
+
+	// Test case: Insertar canción en posición inválida
+	err := storage.InsertSong(6, &voice.Song{Title: "5"})
+	if !errors.Is(err, bot.ErrInvalidPosition) {
+		t.Errorf("Error esperado: %v, Error obtenido: %v", bot.ErrInvalidPosition, err)
+	}
+	err = storage.InsertSong(0, &voice.Song{Title: "5"})
+	if !errors.Is(err, bot.ErrInvalidPosition) {
+		t.Errorf("Error esperado: %v, Error obtenido: %v", bot.ErrInvalidPosition, err)
+	}
+}
+
+func TestInmemorySongStorage_MoveSong(t *testing.T) {
+	mockLogger := new(logging.MockLogger)
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+	storage := NewInmemorySongStorage(mockLogger)
+
+	song1 := &voice.Song{Title: "1"}
+	song2 := &voice.Song{Title: "2"}
+	song3 := &voice.Song{Title: "3"}
+	song4 := &voice.Song{Title: "4"}
+	for _, song := range []*voice.Song{song1, song2, song3, song4} {
+		if err := storage.AppendSong(song); err != nil {
+			t.Errorf("Error al agregar canción: %v", err)
+		}
+	}
+
+	// Test case: Mover canción hacia atrás
+	moved, err := storage.MoveSong(1, 3)
+	if err != nil {
+		t.Errorf("Error al mover canción: %v", err)
+	}
+	if moved.Title != song1.Title {
+		t.Errorf("Canción incorrecta movida. Esperado: %s, Obtenido: %s", song1.Title, moved.Title)
+	}
+	songs, _ := storage.GetSongs()
+	expectedSongs := []*voice.Song{song2, song3, song1, song4}
+	if !reflect.DeepEqual(songs, expectedSongs) {
+		t.Errorf("Canciones obtenidas incorrectas. Esperado: %v, Obtenido: %v", expectedSongs, songs)
+	}
+
+	// Test case: Mover canción hacia adelante
+	if _, err := storage.MoveSong(4, 1); err != nil {
+		t.Errorf("Error al mover canción: %v", err)
+	}
+	songs, _ = storage.GetSongs()
+	expectedSongs = []*voice.Song{song4, song2, song3, song1}
+	if !reflect.DeepEqual(songs, expectedSongs) {
+		t.Errorf("Canciones obtenidas incorrectas. Esperado: %v, Obtenido: %v", expectedSongs, songs)
+	}
+
+	// Test case: Mover canción desde posición inválida
+	_, err = storage.MoveSong(5, 1)
+	if !errors.Is(err, bot.ErrInvalidPosition) {
+		t.Errorf("Error esperado: %v, Error obtenido: %v", bot.ErrInvalidPosition, err)
+	}
+}
+
+func TestInmemorySongStorage_ShuffleSongs(t *testing.T) {
+	mockLogger := new(logging.MockLogger)
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+	storage := NewInmemorySongStorage(mockLogger)
+
+	titles := make(map[string]bool)
+	for i := 0; i < 20; i++ {
+		song := &voice.Song{Title: string(rune('a' + i))}
+		titles[song.Title] = true
+		if err := storage.AppendSong(song); err != nil {
+			t.Errorf("Error al agregar canción: %v", err)
+		}
+	}
+
+	if err := storage.ShuffleSongs(); err != nil {
+		t.Errorf("Error al mezclar canciones: %v", err)
+	}
+
+	// La mezcla conserva todas las canciones
+	songs, _ := storage.GetSongs()
+	assert.Len(t, songs, len(titles))
+	for _, song := range songs {
+		assert.True(t, titles[song.Title])
+	}
+	mockLogger.AssertCalled(t, "Info", "Lista de reproducción mezclada", mock.Anything)
+}
+
+func TestInmemorySongStorage_AppendSong_FairQueue(t *testing.T) {
+	mockLogger := new(logging.MockLogger)
+	storage := NewInmemorySongStorage(mockLogger).WithFairQueue(true)
+
+	mockLogger.On("Info", mock.AnythingOfType("string"), mock.AnythingOfType("[]zapcore.Field")).Return()
+
+	for _, song := range []*voice.Song{
+		{Title: "a1", RequesterID: "ana"},
+		{Title: "a2", RequesterID: "ana"},
+		{Title: "b1", RequesterID: "beto"},
+	} {
+		assert.NoError(t, storage.AppendSong(song))
+	}
+
+	songs, err := storage.GetSongs()
+	assert.NoError(t, err)
+	assert.Equal(t, "a1", songs[0].Title)
+	assert.Equal(t, "b1", songs[1].Title)
+	assert.Equal(t, "a2", songs[2].Title)
+	mockLogger.AssertCalled(t, "Info", "Canción agregada a la lista de reproducción en su turno", mock.AnythingOfType("[]zapcore.Field"))
+}
+
+func TestInmemorySongStorage_AppendSongUnfair(t *testing.T) {
+	mockLogger := new(logging.MockLogger)
+	mockLogger.On("Info", mock.AnythingOfType("string"), mock.AnythingOfType("[]zapcore.Field")).Return()
+	storage := NewInmemorySongStorage(mockLogger).WithFairQueue(true)
+
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "a1", RequesterID: "ana"}))
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "a2", RequesterID: "ana"}))
+	// En la cola justa b1 iría antes que a2, pero AppendSongUnfair siempre agrega al final.
+	assert.NoError(t, storage.AppendSongUnfair(&voice.Song{Title: "b1", RequesterID: "beto"}))
+
+	songs, err := storage.GetSongs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a1", "a2", "b1"}, []string{songs[0].Title, songs[1].Title, songs[2].Title})
+}
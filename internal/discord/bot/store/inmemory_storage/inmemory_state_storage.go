@@ -13,15 +13,21 @@ type InmemoryStateStorage struct {
 	currentSong  *voice.PlayedSong // currentSong es la canción actual que se está reproduciendo.
 	textChannel  string            // textChannel es el ID del canal de texto asociado al servidor.
 	voiceChannel string            // voiceChannel es el ID del canal de voz asociado al servidor.
+	paused       bool              // paused indica si la reproducción actual está en pausa.
+	loopMode     voice.LoopMode    // loopMode es el modo de repetición del reproductor.
+	volume       int               // volume es el volumen del reproductor en porcentaje.
+	autoplay     bool              // autoplay indica si la reproducción automática está activada.
 	logger       logging.Logger    // logger es un registrador para registrar mensajes de depuración y errores.
 }
 
 // NewInmemoryStateStorage crea una nueva instancia de InmemoryStateStorage.
 func NewInmemoryStateStorage(logger logging.Logger) *InmemoryStateStorage {
 	return &InmemoryStateStorage{
-		mutex:  sync.RWMutex{},         // Se inicializa un nuevo mutex para garantizar la concurrencia segura.
-		songs:  make([]*voice.Song, 0), // Se inicializa una nueva lista de reproducción de canciones vacía.
-		logger: logger,                 // Se inicializa un nuevo logger con un logger "Nop" (sin operación) por defecto.
+		mutex:    sync.RWMutex{},         // Se inicializa un nuevo mutex para garantizar la concurrencia segura.
+		songs:    make([]*voice.Song, 0), // Se inicializa una nueva lista de reproducción de canciones vacía.
+		loopMode: voice.LoopModeOff,      // Por defecto no se repite ninguna canción.
+		volume:   voice.DefaultVolume,    // Por defecto el audio se transmite sin ganancia.
+		logger:   logger,                 // Se inicializa un nuevo logger con un logger "Nop" (sin operación) por defecto.
 	}
 }
 
@@ -78,3 +84,75 @@ func (s *InmemoryStateStorage) SetTextChannel(channelID string) error {
 	s.logger.Info("Canal de texto establecido")
 	return nil
 }
+
+// GetPaused indica si la reproducción actual está en pausa.
+func (s *InmemoryStateStorage) GetPaused() (bool, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.paused, nil
+}
+
+// SetPaused establece si la reproducción actual está en pausa.
+func (s *InmemoryStateStorage) SetPaused(paused bool) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.paused = paused
+	s.logger.Info("Estado de pausa establecido")
+	return nil
+}
+
+// GetLoopMode devuelve el modo de repetición del reproductor.
+func (s *InmemoryStateStorage) GetLoopMode() (voice.LoopMode, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.loopMode, nil
+}
+
+// SetLoopMode establece el modo de repetición del reproductor.
+func (s *InmemoryStateStorage) SetLoopMode(mode voice.LoopMode) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.loopMode = mode
+	s.logger.Info("Modo de repetición establecido")
+	return nil
+}
+
+// GetVolume devuelve el volumen del reproductor en porcentaje.
+func (s *InmemoryStateStorage) GetVolume() (int, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.volume, nil
+}
+
+// SetVolume establece el volumen del reproductor en porcentaje.
+func (s *InmemoryStateStorage) SetVolume(volume int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.volume = volume
+	s.logger.Info("Volumen establecido")
+	return nil
+}
+
+// GetAutoplay indica si la reproducción automática está activada.
+func (s *InmemoryStateStorage) GetAutoplay() (bool, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.autoplay, nil
+}
+
+// SetAutoplay activa o desactiva la reproducción automática.
+func (s *InmemoryStateStorage) SetAutoplay(enabled bool) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.autoplay = enabled
+	s.logger.Info("Reproducción automática establecida")
+	return nil
+}
@@ -87,3 +87,123 @@ func TestInmemoryStateStorage_GetTextChannel(t *testing.T) {
 
 	mockLogger.AssertExpectations(t)
 }
+
+// TestInmemoryStateStorage_GetPaused verifica que el método GetPaused devuelva el estado de pausa correctamente.
+func TestInmemoryStateStorage_GetPaused(t *testing.T) {
+	mockLogger := new(logging.MockLogger)
+	mockLogger.On("Info", "Estado de pausa establecido", mock.AnythingOfType("[]zapcore.Field")).Return()
+
+	storage := NewInmemoryStateStorage(mockLogger)
+
+	paused, err := storage.GetPaused()
+	if err != nil {
+		t.Errorf("Error al obtener el estado de pausa: %v", err)
+	}
+	if paused {
+		t.Error("El reproductor no debería iniciar en pausa")
+	}
+
+	if err := storage.SetPaused(true); err != nil {
+		t.Errorf("Error al establecer el estado de pausa: %v", err)
+	}
+
+	paused, err = storage.GetPaused()
+	if err != nil {
+		t.Errorf("Error al obtener el estado de pausa: %v", err)
+	}
+	if !paused {
+		t.Error("El estado de pausa obtenido no coincide con el establecido")
+	}
+
+	mockLogger.AssertExpectations(t)
+}
+
+// TestInmemoryStateStorage_GetLoopMode verifica que el método GetLoopMode devuelva el modo de repetición correctamente.
+func TestInmemoryStateStorage_GetLoopMode(t *testing.T) {
+	mockLogger := new(logging.MockLogger)
+	mockLogger.On("Info", "Modo de repetición establecido", mock.AnythingOfType("[]zapcore.Field")).Return()
+
+	storage := NewInmemoryStateStorage(mockLogger)
+
+	mode, err := storage.GetLoopMode()
+	if err != nil {
+		t.Errorf("Error al obtener el modo de repetición: %v", err)
+	}
+	if mode != voice.LoopModeOff {
+		t.Errorf("El modo de repetición inicial debería ser %s, Obtenido: %s", voice.LoopModeOff, mode)
+	}
+
+	if err := storage.SetLoopMode(voice.LoopModeQueue); err != nil {
+		t.Errorf("Error al establecer el modo de repetición: %v", err)
+	}
+
+	mode, err = storage.GetLoopMode()
+	if err != nil {
+		t.Errorf("Error al obtener el modo de repetición: %v", err)
+	}
+	if mode != voice.LoopModeQueue {
+		t.Errorf("El modo de repetición obtenido no coincide. Esperado: %s, Obtenido: %s", voice.LoopModeQueue, mode)
+	}
+
+	mockLogger.AssertExpectations(t)
+}
+
+// TestInmemoryStateStorage_GetVolume verifica que el método GetVolume devuelva el volumen correctamente.
+func TestInmemoryStateStorage_GetVolume(t *testing.T) {
+	mockLogger := new(logging.MockLogger)
+	mockLogger.On("Info", "Volumen establecido", mock.AnythingOfType("[]zapcore.Field")).Return()
+
+	storage := NewInmemoryStateStorage(mockLogger)
+
+	volume, err := storage.GetVolume()
+	if err != nil {
+		t.Errorf("Error al obtener el volumen: %v", err)
+	}
+	if volume != voice.DefaultVolume {
+		t.Errorf("El volumen inicial debería ser %d, Obtenido: %d", voice.DefaultVolume, volume)
+	}
+
+	if err := storage.SetVolume(150); err != nil {
+		t.Errorf("Error al establecer el volumen: %v", err)
+	}
+
+	volume, err = storage.GetVolume()
+	if err != nil {
+		t.Errorf("Error al obtener el volumen: %v", err)
+	}
+	if volume != 150 {
+		t.Errorf("El volumen obtenido no coincide. Esperado: %d, Obtenido: %d", 150, volume)
+	}
+
+	mockLogger.AssertExpectations(t)
+}
+
+// TestInmemoryStateStorage_GetAutoplay verifica que el método GetAutoplay devuelva el estado de la reproducción automática correctamente.
+func TestInmemoryStateStorage_GetAutoplay(t *testing.T) {
+	mockLogger := new(logging.MockLogger)
+	mockLogger.On("Info", "Reproducción automática establecida", mock.AnythingOfType("[]zapcore.Field")).Return()
+
+	storage := NewInmemoryStateStorage(mockLogger)
+
+	enabled, err := storage.GetAutoplay()
+	if err != nil {
+		t.Errorf("Error al obtener la reproducción automática: %v", err)
+	}
+	if enabled {
+		t.Error("La reproducción automática debería estar desactivada inicialmente")
+	}
+
+	if err := storage.SetAutoplay(true); err != nil {
+		t.Errorf("Error al establecer la reproducción automática: %v", err)
+	}
+
+	enabled, err = storage.GetAutoplay()
+	if err != nil {
+		t.Errorf("Error al obtener la reproducción automática: %v", err)
+	}
+	if !enabled {
+		t.Error("El estado de la reproducción automática obtenido no coincide con el establecido")
+	}
+
+	mockLogger.AssertExpectations(t)
+}
@@ -0,0 +1,280 @@
+package redis_storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot/store"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"math/rand"
+)
+
+// maxTxRetries es la cantidad de veces que se reintenta una transacción si otro proceso modificó la lista de reproducción.
+const maxTxRetries = 5
+
+// RedisSongStorage implementa la interfaz SongStorage guardando la lista de reproducción en una lista de Redis.
+type RedisSongStorage struct {
+	client redis.UniversalClient // client es el cliente de Redis, compartido entre servidores.
+	key    string                // key es la clave de la lista de reproducción del servidor.
+	fair   bool                  // fair indica si las canciones agregadas al final se intercalan por turnos entre quienes las pidieron.
+	logger logging.Logger        // logger es un registrador para registrar mensajes de depuración y errores.
+}
+
+// NewRedisSongStorage crea una nueva instancia de RedisSongStorage para el servidor indicado.
+func NewRedisSongStorage(client redis.UniversalClient, keyPrefix, guildID string, logger logging.Logger) *RedisSongStorage {
+	return &RedisSongStorage{
+		client: client,
+		key:    fmt.Sprintf("%s:%s:songs", keyPrefix, guildID),
+		logger: logger,
+	}
+}
+
+// WithFairQueue activa o desactiva el modo de cola justa, que intercala por turnos las canciones de cada solicitante.
+func (s *RedisSongStorage) WithFairQueue(enabled bool) *RedisSongStorage {
+	s.fair = enabled
+	return s
+}
+
+// PrependSong agrega una canción al principio de la lista de reproducción.
+func (s *RedisSongStorage) PrependSong(song *voice.Song) error {
+	data, err := json.Marshal(song)
+	if err != nil {
+		return fmt.Errorf("al codificar la canción: %w", err)
+	}
+	if err := s.client.LPush(context.Background(), s.key, data).Err(); err != nil {
+		s.logger.Error("Error al agregar la canción en Redis", zap.Error(err))
+		return fmt.Errorf("al agregar la canción: %w", err)
+	}
+	s.logger.Info("Canción agregada al principio de la lista de reproducción")
+	return nil
+}
+
+// AppendSong agrega una canción al final de la lista de reproducción.
+// En el modo de cola justa la canción se agrega al final de su turno.
+func (s *RedisSongStorage) AppendSong(song *voice.Song) error {
+	if s.fair {
+		if err := s.rewrite(func(songs []*voice.Song) ([]*voice.Song, error) {
+			index := store.FairInsertIndex(songs, song)
+			return append(songs[:index], append([]*voice.Song{song}, songs[index:]...)...), nil
+		}); err != nil {
+			return err
+		}
+		s.logger.Info("Canción agregada a la lista de reproducción en su turno")
+		return nil
+	}
+	return s.AppendSongUnfair(song)
+}
+
+// AppendSongUnfair agrega una canción al final de la lista de reproducción, aun en el modo de cola justa.
+func (s *RedisSongStorage) AppendSongUnfair(song *voice.Song) error {
+	data, err := json.Marshal(song)
+	if err != nil {
+		return fmt.Errorf("al codificar la canción: %w", err)
+	}
+	if err := s.client.RPush(context.Background(), s.key, data).Err(); err != nil {
+		s.logger.Error("Error al agregar la canción en Redis", zap.Error(err))
+		return fmt.Errorf("al agregar la canción: %w", err)
+	}
+	s.logger.Info("Canción agregada al final de la lista de reproducción")
+	return nil
+}
+
+// RemoveSong elimina una canción de la lista de reproducción por posición.
+func (s *RedisSongStorage) RemoveSong(position int) (*voice.Song, error) {
+	return s.removeSong(position, nil)
+}
+
+// RemoveRequesterSong elimina la canción de la posición indicada solo si la agregó el usuario indicado.
+func (s *RedisSongStorage) RemoveRequesterSong(position int, requesterID string) (*voice.Song, error) {
+	return s.removeSong(position, &requesterID)
+}
+
+// removeSong elimina la canción de la posición indicada. Si requesterID no es nil, solo la elimina si la agregó ese usuario.
+func (s *RedisSongStorage) removeSong(position int, requesterID *string) (*voice.Song, error) {
+	index := position - 1
+
+	var song *voice.Song
+	if err := s.rewrite(func(songs []*voice.Song) ([]*voice.Song, error) {
+		if index >= len(songs) || index < 0 {
+			return nil, bot.ErrRemoveInvalidPosition
+		}
+		if requesterID != nil && songs[index].RequesterID != *requesterID {
+			return nil, bot.ErrNotSongOwner
+		}
+		song = songs[index]
+		return append(songs[:index], songs[index+1:]...), nil
+	}); err != nil {
+		s.logger.Info("No se pudo eliminar la canción de la lista de reproducción")
+		return nil, err
+	}
+	s.logger.Info("Canción eliminada de la lista de reproducción")
+	return song, nil
+}
+
+// ClearPlaylist elimina todas las canciones de la lista de reproducción.
+func (s *RedisSongStorage) ClearPlaylist() error {
+	if err := s.client.Del(context.Background(), s.key).Err(); err != nil {
+		s.logger.Error("Error al borrar la lista de reproducción en Redis", zap.Error(err))
+		return fmt.Errorf("al borrar la lista de reproducción: %w", err)
+	}
+	s.logger.Info("Lista de reproducción borrada")
+	return nil
+}
+
+// GetSongs devuelve todas las canciones de la lista de reproducción.
+func (s *RedisSongStorage) GetSongs() ([]*voice.Song, error) {
+	values, err := s.client.LRange(context.Background(), s.key, 0, -1).Result()
+	if err != nil {
+		s.logger.Error("Error al obtener la lista de reproducción de Redis", zap.Error(err))
+		return nil, fmt.Errorf("al obtener canciones: %w", err)
+	}
+	songs, err := decodeSongs(values)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("Obteniendo todas las canciones de la lista de reproducción")
+	return songs, nil
+}
+
+// PopFirstSong elimina y devuelve la primera canción de la lista de reproducción.
+func (s *RedisSongStorage) PopFirstSong() (*voice.Song, error) {
+	value, err := s.client.LPop(context.Background(), s.key).Result()
+	if errors.Is(err, redis.Nil) {
+		s.logger.Info("No hay canciones para eliminar")
+		return nil, bot.ErrNoSongs
+	}
+	if err != nil {
+		s.logger.Error("Error al obtener la primera canción de Redis", zap.Error(err))
+		return nil, fmt.Errorf("al obtener la primera canción: %w", err)
+	}
+
+	var song voice.Song
+	if err := json.Unmarshal([]byte(value), &song); err != nil {
+		return nil, fmt.Errorf("al decodificar la canción: %w", err)
+	}
+	s.logger.Info("Primera canción eliminada de la lista de reproducción")
+	return &song, nil
+}
+
+// InsertSong inserta una canción en la posición indicada de la lista de reproducción.
+// La posición comienza en 1 y puede ser como máximo la cantidad de canciones más uno.
+func (s *RedisSongStorage) InsertSong(position int, song *voice.Song) error {
+	index := position - 1
+
+	if err := s.rewrite(func(songs []*voice.Song) ([]*voice.Song, error) {
+		if index > len(songs) || index < 0 {
+			return nil, bot.ErrInvalidPosition
+		}
+		return append(songs[:index], append([]*voice.Song{song}, songs[index:]...)...), nil
+	}); err != nil {
+		s.logger.Info("No se pudo insertar la canción en la lista de reproducción")
+		return err
+	}
+	s.logger.Info("Canción insertada en la lista de reproducción")
+	return nil
+}
+
+// MoveSong mueve una canción de una posición a otra de la lista de reproducción.
+func (s *RedisSongStorage) MoveSong(from, to int) (*voice.Song, error) {
+	fromIndex, toIndex := from-1, to-1
+
+	var song *voice.Song
+	if err := s.rewrite(func(songs []*voice.Song) ([]*voice.Song, error) {
+		if fromIndex >= len(songs) || fromIndex < 0 || toIndex >= len(songs) || toIndex < 0 {
+			return nil, bot.ErrInvalidPosition
+		}
+		song = songs[fromIndex]
+		if fromIndex < toIndex {
+			copy(songs[fromIndex:toIndex], songs[fromIndex+1:toIndex+1])
+		} else {
+			copy(songs[toIndex+1:fromIndex+1], songs[toIndex:fromIndex])
+		}
+		songs[toIndex] = song
+		return songs, nil
+	}); err != nil {
+		s.logger.Info("No se pudo mover la canción en la lista de reproducción")
+		return nil, err
+	}
+	s.logger.Info("Canción movida en la lista de reproducción")
+	return song, nil
+}
+
+// ShuffleSongs mezcla aleatoriamente las canciones de la lista de reproducción.
+func (s *RedisSongStorage) ShuffleSongs() error {
+	if err := s.rewrite(func(songs []*voice.Song) ([]*voice.Song, error) {
+		rand.Shuffle(len(songs), func(i, j int) {
+			songs[i], songs[j] = songs[j], songs[i]
+		})
+		return songs, nil
+	}); err != nil {
+		return err
+	}
+	s.logger.Info("Lista de reproducción mezclada")
+	return nil
+}
+
+// rewrite lee la lista de reproducción, le aplica fn y la reemplaza en una transacción.
+// Si otro proceso modifica la lista mientras tanto, la operación se reintenta.
+func (s *RedisSongStorage) rewrite(fn func(songs []*voice.Song) ([]*voice.Song, error)) error {
+	ctx := context.Background()
+	txf := func(tx *redis.Tx) error {
+		values, err := tx.LRange(ctx, s.key, 0, -1).Result()
+		if err != nil {
+			return err
+		}
+		songs, err := decodeSongs(values)
+		if err != nil {
+			return err
+		}
+		songs, err = fn(songs)
+		if err != nil {
+			return err
+		}
+
+		encoded := make([]interface{}, len(songs))
+		for i, song := range songs {
+			if encoded[i], err = json.Marshal(song); err != nil {
+				return fmt.Errorf("al codificar la canción: %w", err)
+			}
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(ctx, s.key)
+			if len(encoded) > 0 {
+				pipe.RPush(ctx, s.key, encoded...)
+			}
+			return nil
+		})
+		return err
+	}
+
+	for i := 0; i < maxTxRetries; i++ {
+		err := s.client.Watch(ctx, txf, s.key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		// Las posiciones inválidas y las canciones de otros usuarios son errores de quien usa el comando, no del store.
+		if err != nil && !errors.Is(err, bot.ErrRemoveInvalidPosition) && !errors.Is(err, bot.ErrInvalidPosition) && !errors.Is(err, bot.ErrNotSongOwner) {
+			s.logger.Error("Error al modificar la lista de reproducción en Redis", zap.Error(err))
+		}
+		return err
+	}
+	return fmt.Errorf("al modificar la lista de reproducción: %w", redis.TxFailedErr)
+}
+
+// decodeSongs decodifica las canciones guardadas en la lista de Redis.
+func decodeSongs(values []string) ([]*voice.Song, error) {
+	songs := make([]*voice.Song, len(values))
+	for i, value := range values {
+		var song voice.Song
+		if err := json.Unmarshal([]byte(value), &song); err != nil {
+			return nil, fmt.Errorf("al decodificar la canción: %w", err)
+		}
+		songs[i] = &song
+	}
+	return songs, nil
+}
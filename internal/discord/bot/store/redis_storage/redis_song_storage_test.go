@@ -0,0 +1,170 @@
+package redis_storage
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/Tomas-vilte/GoMusicBot/internal/utils"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func newTestClient(t *testing.T) (*miniredis.Miniredis, redis.UniversalClient) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return server, client
+}
+
+func newTestLogger() *logging.MockLogger {
+	mockLogger := new(logging.MockLogger)
+	mockLogger.On("Info", mock.AnythingOfType("string"), mock.AnythingOfType("[]zapcore.Field")).Return()
+	mockLogger.On("Error", mock.AnythingOfType("string"), mock.AnythingOfType("[]zapcore.Field")).Return()
+	return mockLogger
+}
+
+func titles(songs []*voice.Song) []string {
+	result := make([]string, len(songs))
+	for i, song := range songs {
+		result[i] = song.Title
+	}
+	return result
+}
+
+func TestRedisSongStorage_AppendAndPrepend(t *testing.T) {
+	server, client := newTestClient(t)
+	storage := NewRedisSongStorage(client, "butakero", "guild", newTestLogger())
+
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "1", RequestedBy: utils.String("ana")}))
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "2"}))
+	assert.NoError(t, storage.PrependSong(&voice.Song{Title: "0"}))
+
+	songs, err := storage.GetSongs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0", "1", "2"}, titles(songs))
+	assert.Equal(t, "ana", *songs[1].RequestedBy)
+
+	// La lista de reproducción se guarda como una lista de Redis.
+	values, err := server.List("butakero:guild:songs")
+	assert.NoError(t, err)
+	assert.Len(t, values, 3)
+}
+
+func TestRedisSongStorage_SharedBetweenInstances(t *testing.T) {
+	_, client := newTestClient(t)
+	first := NewRedisSongStorage(client, "butakero", "guild", newTestLogger())
+	second := NewRedisSongStorage(client, "butakero", "guild", newTestLogger())
+	other := NewRedisSongStorage(client, "butakero", "otro", newTestLogger())
+
+	assert.NoError(t, first.AppendSong(&voice.Song{Title: "1"}))
+
+	songs, err := second.GetSongs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, titles(songs))
+	songs, err = other.GetSongs()
+	assert.NoError(t, err)
+	assert.Empty(t, songs)
+}
+
+func TestRedisSongStorage_RemoveSong(t *testing.T) {
+	_, client := newTestClient(t)
+	storage := NewRedisSongStorage(client, "butakero", "guild", newTestLogger())
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "1"}))
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "2"}))
+
+	song, err := storage.RemoveSong(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", song.Title)
+
+	_, err = storage.RemoveSong(3)
+	assert.ErrorIs(t, err, bot.ErrRemoveInvalidPosition)
+
+	songs, _ := storage.GetSongs()
+	assert.Equal(t, []string{"2"}, titles(songs))
+}
+
+func TestRedisSongStorage_RemoveRequesterSong(t *testing.T) {
+	_, client := newTestClient(t)
+	logger := newTestLogger()
+	storage := NewRedisSongStorage(client, "butakero", "guild", logger)
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "1", RequesterID: "ana"}))
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "2", RequesterID: "beto"}))
+
+	_, err := storage.RemoveRequesterSong(1, "beto")
+	assert.ErrorIs(t, err, bot.ErrNotSongOwner)
+	_, err = storage.RemoveRequesterSong(3, "beto")
+	assert.ErrorIs(t, err, bot.ErrRemoveInvalidPosition)
+
+	// Los errores de quien usa el comando no se registran como errores del store.
+	logger.AssertNotCalled(t, "Error", mock.Anything, mock.Anything)
+
+	song, err := storage.RemoveRequesterSong(2, "beto")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", song.Title)
+
+	songs, _ := storage.GetSongs()
+	assert.Equal(t, []string{"1"}, titles(songs))
+}
+
+func TestRedisSongStorage_PopFirstSong(t *testing.T) {
+	_, client := newTestClient(t)
+	storage := NewRedisSongStorage(client, "butakero", "guild", newTestLogger())
+
+	_, err := storage.PopFirstSong()
+	assert.ErrorIs(t, err, bot.ErrNoSongs)
+
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "1"}))
+	song, err := storage.PopFirstSong()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", song.Title)
+}
+
+func TestRedisSongStorage_InsertMoveAndClear(t *testing.T) {
+	_, client := newTestClient(t)
+	storage := NewRedisSongStorage(client, "butakero", "guild", newTestLogger())
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "a"}))
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "c"}))
+
+	assert.NoError(t, storage.InsertSong(2, &voice.Song{Title: "b"}))
+	assert.ErrorIs(t, storage.InsertSong(5, &voice.Song{}), bot.ErrInvalidPosition)
+
+	song, err := storage.MoveSong(3, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "c", song.Title)
+	_, err = storage.MoveSong(0, 1)
+	assert.ErrorIs(t, err, bot.ErrInvalidPosition)
+
+	songs, _ := storage.GetSongs()
+	assert.Equal(t, []string{"c", "a", "b"}, titles(songs))
+
+	assert.NoError(t, storage.ShuffleSongs())
+	songs, _ = storage.GetSongs()
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, titles(songs))
+
+	assert.NoError(t, storage.ClearPlaylist())
+	songs, _ = storage.GetSongs()
+	assert.Empty(t, songs)
+}
+
+func TestRedisSongStorage_FairQueue(t *testing.T) {
+	_, client := newTestClient(t)
+	storage := NewRedisSongStorage(client, "butakero", "guild", newTestLogger()).WithFairQueue(true)
+
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "a1", RequesterID: "ana"}))
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "a2", RequesterID: "ana"}))
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "b1", RequesterID: "beto"}))
+
+	songs, err := storage.GetSongs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a1", "b1", "a2"}, titles(songs))
+
+	// AppendSongUnfair agrega al final aunque a beto le toque un turno antes.
+	assert.NoError(t, storage.AppendSongUnfair(&voice.Song{Title: "b2", RequesterID: "beto"}))
+	assert.NoError(t, storage.AppendSong(&voice.Song{Title: "c1", RequesterID: "carla"}))
+	songs, err = storage.GetSongs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a1", "b1", "c1", "a2", "b2"}, titles(songs))
+}
@@ -0,0 +1,199 @@
+package redis_storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+// Campos del hash de Redis con el estado del reproductor.
+const (
+	fieldCurrentSong  = "current_song"
+	fieldVoiceChannel = "voice_channel"
+	fieldTextChannel  = "text_channel"
+	fieldPaused       = "paused"
+	fieldLoopMode     = "loop_mode"
+	fieldVolume       = "volume"
+	fieldAutoplay     = "autoplay"
+)
+
+// RedisStateStorage implementa la interfaz StateStorage guardando el estado del reproductor en un hash de Redis.
+type RedisStateStorage struct {
+	client redis.UniversalClient // client es el cliente de Redis, compartido entre servidores.
+	key    string                // key es la clave del hash con el estado del servidor.
+	logger logging.Logger        // logger es un registrador para registrar mensajes de depuración y errores.
+}
+
+// NewRedisStateStorage crea una nueva instancia de RedisStateStorage para el servidor indicado.
+func NewRedisStateStorage(client redis.UniversalClient, keyPrefix, guildID string, logger logging.Logger) *RedisStateStorage {
+	return &RedisStateStorage{
+		client: client,
+		key:    fmt.Sprintf("%s:%s:state", keyPrefix, guildID),
+		logger: logger,
+	}
+}
+
+// GetCurrentSong devuelve la canción actual que se está reproduciendo.
+func (s *RedisStateStorage) GetCurrentSong() (*voice.PlayedSong, error) {
+	value, ok, err := s.get(fieldCurrentSong)
+	if err != nil || !ok {
+		return nil, err
+	}
+	var song voice.PlayedSong
+	if err := json.Unmarshal([]byte(value), &song); err != nil {
+		return nil, fmt.Errorf("al decodificar la canción actual: %w", err)
+	}
+	return &song, nil
+}
+
+// SetCurrentSong establece la canción actual que se está reproduciendo.
+func (s *RedisStateStorage) SetCurrentSong(song *voice.PlayedSong) error {
+	if song == nil {
+		if err := s.client.HDel(context.Background(), s.key, fieldCurrentSong).Err(); err != nil {
+			s.logger.Error("Error al borrar la canción actual en Redis", zap.Error(err))
+			return fmt.Errorf("al borrar la canción actual: %w", err)
+		}
+		return nil
+	}
+	data, err := json.Marshal(song)
+	if err != nil {
+		return fmt.Errorf("al codificar la canción actual: %w", err)
+	}
+	return s.set(fieldCurrentSong, data)
+}
+
+// GetVoiceChannel devuelve el ID del canal de voz.
+func (s *RedisStateStorage) GetVoiceChannel() (string, error) {
+	value, _, err := s.get(fieldVoiceChannel)
+	return value, err
+}
+
+// SetVoiceChannel establece el ID del canal de voz.
+func (s *RedisStateStorage) SetVoiceChannel(channelID string) error {
+	if err := s.set(fieldVoiceChannel, channelID); err != nil {
+		return err
+	}
+	s.logger.Info("Canal de voz establecido")
+	return nil
+}
+
+// GetTextChannel devuelve el ID del canal de texto.
+func (s *RedisStateStorage) GetTextChannel() (string, error) {
+	value, _, err := s.get(fieldTextChannel)
+	return value, err
+}
+
+// SetTextChannel establece el ID del canal de texto.
+func (s *RedisStateStorage) SetTextChannel(channelID string) error {
+	if err := s.set(fieldTextChannel, channelID); err != nil {
+		return err
+	}
+	s.logger.Info("Canal de texto establecido")
+	return nil
+}
+
+// GetPaused indica si la reproducción actual está en pausa.
+func (s *RedisStateStorage) GetPaused() (bool, error) {
+	value, ok, err := s.get(fieldPaused)
+	if err != nil || !ok {
+		return false, err
+	}
+	return strconv.ParseBool(value)
+}
+
+// SetPaused establece si la reproducción actual está en pausa.
+func (s *RedisStateStorage) SetPaused(paused bool) error {
+	if err := s.set(fieldPaused, strconv.FormatBool(paused)); err != nil {
+		return err
+	}
+	s.logger.Info("Estado de pausa establecido")
+	return nil
+}
+
+// GetLoopMode devuelve el modo de repetición del reproductor.
+func (s *RedisStateStorage) GetLoopMode() (voice.LoopMode, error) {
+	value, ok, err := s.get(fieldLoopMode)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return voice.LoopModeOff, nil
+	}
+	return voice.LoopMode(value), nil
+}
+
+// SetLoopMode establece el modo de repetición del reproductor.
+func (s *RedisStateStorage) SetLoopMode(mode voice.LoopMode) error {
+	if err := s.set(fieldLoopMode, string(mode)); err != nil {
+		return err
+	}
+	s.logger.Info("Modo de repetición establecido")
+	return nil
+}
+
+// GetVolume devuelve el volumen del reproductor en porcentaje.
+func (s *RedisStateStorage) GetVolume() (int, error) {
+	value, ok, err := s.get(fieldVolume)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return voice.DefaultVolume, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// SetVolume establece el volumen del reproductor en porcentaje.
+func (s *RedisStateStorage) SetVolume(volume int) error {
+	if err := s.set(fieldVolume, strconv.Itoa(volume)); err != nil {
+		return err
+	}
+	s.logger.Info("Volumen establecido")
+	return nil
+}
+
+// GetAutoplay indica si la reproducción automática está activada.
+func (s *RedisStateStorage) GetAutoplay() (bool, error) {
+	value, ok, err := s.get(fieldAutoplay)
+	if err != nil || !ok {
+		return false, err
+	}
+	return strconv.ParseBool(value)
+}
+
+// SetAutoplay activa o desactiva la reproducción automática.
+func (s *RedisStateStorage) SetAutoplay(enabled bool) error {
+	if err := s.set(fieldAutoplay, strconv.FormatBool(enabled)); err != nil {
+		return err
+	}
+	s.logger.Info("Reproducción automática establecida")
+	return nil
+}
+
+// get devuelve el valor de un campo del estado e indica si existe.
+func (s *RedisStateStorage) get(field string) (string, bool, error) {
+	value, err := s.client.HGet(context.Background(), s.key, field).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", false, nil
+	}
+	if err != nil {
+		s.logger.Error("Error al obtener el estado de Redis", zap.String("campo", field), zap.Error(err))
+		return "", false, fmt.Errorf("al obtener %s: %w", field, err)
+	}
+	return value, true, nil
+}
+
+// set guarda el valor de un campo del estado.
+func (s *RedisStateStorage) set(field string, value interface{}) error {
+	if err := s.client.HSet(context.Background(), s.key, field, value).Err(); err != nil {
+		s.logger.Error("Error al guardar el estado en Redis", zap.String("campo", field), zap.Error(err))
+		return fmt.Errorf("al guardar %s: %w", field, err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package redis_storage
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRedisStateStorage_Defaults(t *testing.T) {
+	_, client := newTestClient(t)
+	storage := NewRedisStateStorage(client, "butakero", "guild", newTestLogger())
+
+	currentSong, err := storage.GetCurrentSong()
+	assert.NoError(t, err)
+	assert.Nil(t, currentSong)
+	paused, _ := storage.GetPaused()
+	assert.False(t, paused)
+	loopMode, _ := storage.GetLoopMode()
+	assert.Equal(t, voice.LoopModeOff, loopMode)
+	volume, _ := storage.GetVolume()
+	assert.Equal(t, voice.DefaultVolume, volume)
+	autoplay, _ := storage.GetAutoplay()
+	assert.False(t, autoplay)
+}
+
+func TestRedisStateStorage_SetAndGet(t *testing.T) {
+	server, client := newTestClient(t)
+	storage := NewRedisStateStorage(client, "butakero", "guild", newTestLogger())
+
+	assert.NoError(t, storage.SetCurrentSong(&voice.PlayedSong{Song: voice.Song{Title: "actual"}, Position: 30 * time.Second}))
+	assert.NoError(t, storage.SetVoiceChannel("voz"))
+	assert.NoError(t, storage.SetTextChannel("texto"))
+	assert.NoError(t, storage.SetPaused(true))
+	assert.NoError(t, storage.SetLoopMode(voice.LoopModeTrack))
+	assert.NoError(t, storage.SetVolume(150))
+	assert.NoError(t, storage.SetAutoplay(true))
+
+	// Otra instancia, como la de otro proceso, ve el mismo estado.
+	reopened := NewRedisStateStorage(client, "butakero", "guild", newTestLogger())
+	currentSong, err := reopened.GetCurrentSong()
+	assert.NoError(t, err)
+	assert.Equal(t, "actual", currentSong.Title)
+	assert.Equal(t, 30*time.Second, currentSong.Position)
+	voiceChannel, _ := reopened.GetVoiceChannel()
+	assert.Equal(t, "voz", voiceChannel)
+	textChannel, _ := reopened.GetTextChannel()
+	assert.Equal(t, "texto", textChannel)
+	paused, _ := reopened.GetPaused()
+	assert.True(t, paused)
+	loopMode, _ := reopened.GetLoopMode()
+	assert.Equal(t, voice.LoopModeTrack, loopMode)
+	volume, _ := reopened.GetVolume()
+	assert.Equal(t, 150, volume)
+	autoplay, _ := reopened.GetAutoplay()
+	assert.True(t, autoplay)
+
+	// El estado se guarda como un hash de Redis.
+	assert.Equal(t, "voz", server.HGet("butakero:guild:state", "voice_channel"))
+}
+
+func TestRedisStateStorage_ClearCurrentSong(t *testing.T) {
+	_, client := newTestClient(t)
+	storage := NewRedisStateStorage(client, "butakero", "guild", newTestLogger())
+
+	assert.NoError(t, storage.SetCurrentSong(&voice.PlayedSong{Song: voice.Song{Title: "actual"}}))
+	assert.NoError(t, storage.SetCurrentSong(nil))
+
+	currentSong, err := storage.GetCurrentSong()
+	assert.NoError(t, err)
+	assert.Nil(t, currentSong)
+}
+
+func TestRedisStateStorage_ConnectionError(t *testing.T) {
+	server, client := newTestClient(t)
+	storage := NewRedisStateStorage(client, "butakero", "guild", newTestLogger())
+	server.Close()
+
+	_, err := storage.GetVolume()
+	assert.Error(t, err)
+	assert.Error(t, storage.SetVolume(50))
+}
@@ -8,12 +8,23 @@ type SongStorage interface {
 	PrependSong(*voice.Song) error
 	// AppendSong agrega una canción al final de la lista de reproducción.
 	AppendSong(*voice.Song) error
+	// AppendSongUnfair agrega una canción al final de la lista de reproducción, aun en el modo de cola justa.
+	AppendSongUnfair(*voice.Song) error
 	// RemoveSong elimina una canción de la lista de reproducción por su posición.
 	RemoveSong(int) (*voice.Song, error)
+	// RemoveRequesterSong elimina la canción de la posición indicada solo si la agregó el usuario indicado.
+	// La verificación y la eliminación se hacen en un solo paso, así la posición no puede cambiar en el medio.
+	RemoveRequesterSong(position int, requesterID string) (*voice.Song, error)
 	// ClearPlaylist elimina todas las canciones de la lista de reproducción.
 	ClearPlaylist() error
 	// GetSongs devuelve todas las canciones en la lista de reproducción.
 	GetSongs() ([]*voice.Song, error)
 	// PopFirstSong elimina y devuelve la primera canción de la lista de reproducción.
 	PopFirstSong() (*voice.Song, error)
+	// InsertSong inserta una canción en la posición indicada de la lista de reproducción.
+	InsertSong(int, *voice.Song) error
+	// MoveSong mueve una canción de una posición a otra y devuelve la canción movida.
+	MoveSong(from, to int) (*voice.Song, error)
+	// ShuffleSongs mezcla aleatoriamente las canciones de la lista de reproducción.
+	ShuffleSongs() error
 }
@@ -16,4 +16,20 @@ type StateStorage interface {
 	GetTextChannel() (string, error)
 	// SetTextChannel establece el ID del canal de texto actual.
 	SetTextChannel(string) error
+	// GetPaused indica si la reproducción actual está en pausa.
+	GetPaused() (bool, error)
+	// SetPaused establece si la reproducción actual está en pausa.
+	SetPaused(bool) error
+	// GetLoopMode devuelve el modo de repetición del reproductor.
+	GetLoopMode() (voice.LoopMode, error)
+	// SetLoopMode establece el modo de repetición del reproductor.
+	SetLoopMode(voice.LoopMode) error
+	// GetVolume devuelve el volumen del reproductor en porcentaje.
+	GetVolume() (int, error)
+	// SetVolume establece el volumen del reproductor en porcentaje.
+	SetVolume(int) error
+	// GetAutoplay indica si la reproducción automática está activada.
+	GetAutoplay() (bool, error)
+	// SetAutoplay activa o desactiva la reproducción automática.
+	SetAutoplay(bool) error
 }
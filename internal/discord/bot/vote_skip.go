@@ -0,0 +1,123 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"go.uber.org/zap"
+	"math"
+)
+
+var (
+	// ErrNotListening indica que el usuario no está en el canal de voz del bot.
+	ErrNotListening = errors.New("el usuario no está escuchando en el canal de voz")
+	// ErrAlreadyVoted indica que el usuario ya votó para saltar la canción actual.
+	ErrAlreadyVoted = errors.New("el usuario ya votó para saltar la canción")
+)
+
+// SkipVoteResult es el resultado de un voto para saltar la canción actual.
+type SkipVoteResult struct {
+	Votes    int  // Votos registrados para la canción actual.
+	Required int  // Votos necesarios para saltarla.
+	Skipped  bool // Indica si la canción fue saltada.
+}
+
+// WithVoteSkip establece la fracción de oyentes que debe votar para saltar una canción.
+// Un ratio menor o igual a cero desactiva la votación y cualquiera puede saltar la canción.
+func (p *GuildPlayer) WithVoteSkip(ratio float64) *GuildPlayer {
+	p.voteSkipRatio = math.Min(ratio, 1)
+	return p
+}
+
+// VoteSkip registra el voto del usuario para saltar la canción actual y la salta al alcanzar los votos necesarios.
+// Quien pidió la canción la salta directamente.
+func (p *GuildPlayer) VoteSkip(userID string) (*SkipVoteResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	currentSong, err := p.stateStorage.GetCurrentSong()
+	if err != nil {
+		p.logger.Error("Error al obtener la canción actual", zap.Error(err))
+		return nil, fmt.Errorf("al obtener la canción actual: %w", err)
+	}
+	if currentSong == nil || p.songCtxCancel == nil {
+		return nil, ErrNotPlaying
+	}
+
+	listeners := p.listenerIDs()
+	if p.voteSkipRatio <= 0 || len(listeners) == 0 || currentSong.RequesterID == userID {
+		p.cancelSong(interruptionSkip)
+		p.logger.Info("Canción actual saltada", zap.String("usuario", userID))
+		return &SkipVoteResult{Votes: 1, Required: 1, Skipped: true}, nil
+	}
+
+	if _, ok := listeners[userID]; !ok {
+		return nil, ErrNotListening
+	}
+	if _, ok := p.skipVotes[userID]; ok {
+		return nil, ErrAlreadyVoted
+	}
+	p.skipVotes[userID] = struct{}{}
+
+	// Los votos de quienes salieron del canal de voz ya no cuentan.
+	votes := 0
+	for id := range p.skipVotes {
+		if _, ok := listeners[id]; ok {
+			votes++
+		}
+	}
+	required := int(math.Max(1, math.Ceil(p.voteSkipRatio*float64(len(listeners)))))
+	result := &SkipVoteResult{Votes: votes, Required: required, Skipped: votes >= required}
+
+	p.logger.Info("Voto para saltar la canción registrado", zap.String("usuario", userID), zap.Int("votos", votes), zap.Int("requeridos", required))
+	p.updateSkipVoteMessage(&currentSong.Song, result)
+	if result.Skipped {
+		p.cancelSong(interruptionSkip)
+		p.logger.Info("Canción actual saltada por votación")
+	}
+	return result, nil
+}
+
+// listenerIDs devuelve los IDs de los usuarios que escuchan en el canal de voz del bot, sin contar bots.
+// Debe llamarse con p.mu tomado.
+func (p *GuildPlayer) listenerIDs() map[string]struct{} {
+	listeners := make(map[string]struct{})
+	for _, info := range p.voiceChannelMap {
+		for _, member := range info.Members {
+			if member == nil || member.User == nil || member.User.Bot || member.User.ID == info.BotID {
+				continue
+			}
+			listeners[member.User.ID] = struct{}{}
+		}
+	}
+	return listeners
+}
+
+// updateSkipVoteMessage envía o actualiza el mensaje con el recuento de votos en el canal de texto.
+// Debe llamarse con p.mu tomado.
+func (p *GuildPlayer) updateSkipVoteMessage(song *voice.Song, result *SkipVoteResult) {
+	textChannel, err := p.stateStorage.GetTextChannel()
+	if err != nil {
+		p.logger.Error("Error al obtener el canal de texto", zap.Error(err))
+		return
+	}
+
+	embed := voice.GenerateSkipVoteEmbed(song, result.Votes, result.Required, result.Skipped)
+	if p.skipVoteMsgID != "" {
+		if err := p.message.EditEmbed(textChannel, p.skipVoteMsgID, embed); err == nil {
+			return
+		}
+	}
+	msgID, err := p.message.SendEmbed(textChannel, embed)
+	if err != nil {
+		p.logger.Error("Error al enviar el recuento de votos", zap.Error(err))
+		return
+	}
+	p.skipVoteMsgID = msgID
+}
+
+// resetSkipVotes descarta los votos de la canción anterior. Debe llamarse con p.mu tomado.
+func (p *GuildPlayer) resetSkipVotes() {
+	p.skipVotes = make(map[string]struct{})
+	p.skipVoteMsgID = ""
+}
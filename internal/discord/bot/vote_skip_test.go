@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot/store"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/discordmessenger"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+// votingStateStorage es un almacenamiento de estado de prueba con una canción sonando.
+type votingStateStorage struct {
+	store.StateStorage
+	current *voice.PlayedSong
+}
+
+func (s votingStateStorage) GetCurrentSong() (*voice.PlayedSong, error) { return s.current, nil }
+func (votingStateStorage) GetTextChannel() (string, error)              { return "texto", nil }
+
+// embedMessageSender es un mensajero de prueba que acepta los embeds sin enviarlos.
+type embedMessageSender struct {
+	discordmessenger.ChatMessageSender
+}
+
+func (embedMessageSender) SendEmbed(string, *discordgo.MessageEmbed) (string, error) {
+	return "votos", nil
+}
+func (embedMessageSender) EditEmbed(string, string, *discordgo.MessageEmbed) error { return nil }
+
+// newVotingPlayer crea un reproductor con una canción pedida por requesterID sonando y los oyentes indicados.
+// El contador devuelto registra las veces que se saltó la canción.
+func newVotingPlayer(ratio float64, requesterID string, listeners ...string) (*GuildPlayer, *int) {
+	logger := new(logging.MockLogger)
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+
+	skips := 0
+	p := (&GuildPlayer{
+		stateStorage:    votingStateStorage{current: &voice.PlayedSong{Song: voice.Song{Title: "tema", RequesterID: requesterID}}},
+		message:         embedMessageSender{},
+		logger:          logger,
+		skipVotes:       make(map[string]struct{}),
+		voiceChannelMap: make(map[string]VoiceChannelInfo),
+		songCtxCancel:   func() { skips++ },
+	}).WithVoteSkip(ratio)
+	setListeners(p, listeners...)
+	return p, &skips
+}
+
+// setListeners reemplaza los oyentes del canal de voz del bot.
+func setListeners(p *GuildPlayer, listeners ...string) {
+	members := []*discordgo.Member{{User: &discordgo.User{ID: "bot"}}}
+	for _, id := range listeners {
+		members = append(members, &discordgo.Member{User: &discordgo.User{ID: id}})
+	}
+	p.voiceChannelMap["guild"] = VoiceChannelInfo{BotID: "bot", Members: members}
+}
+
+func TestGuildPlayer_ListenerIDs(t *testing.T) {
+	p := &GuildPlayer{
+		voiceChannelMap: map[string]VoiceChannelInfo{
+			"guild": {
+				BotID: "bot",
+				Members: []*discordgo.Member{
+					{User: &discordgo.User{ID: "bot"}},
+					{User: &discordgo.User{ID: "otro-bot", Bot: true}},
+					{User: &discordgo.User{ID: "usuario1"}},
+					{User: &discordgo.User{ID: "usuario2"}},
+				},
+			},
+		},
+	}
+
+	listeners := p.listenerIDs()
+
+	assert.Len(t, listeners, 2)
+	assert.Contains(t, listeners, "usuario1")
+	assert.Contains(t, listeners, "usuario2")
+}
+
+func TestGuildPlayer_VoteSkip_RequiredVotes(t *testing.T) {
+	tests := []struct {
+		name      string
+		ratio     float64
+		listeners []string
+		required  int
+	}{
+		{"mitad de cuatro", 0.5, []string{"a", "b", "c", "d"}, 2},
+		{"mitad de tres redondea para arriba", 0.5, []string{"a", "b", "c"}, 2},
+		{"sesenta por ciento de cuatro", 0.6, []string{"a", "b", "c", "d"}, 3},
+		{"todos", 1, []string{"a", "b", "c"}, 3},
+		{"ratio mínimo pide al menos un voto", 0.01, []string{"a", "b"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, skips := newVotingPlayer(tt.ratio, "otro", tt.listeners...)
+
+			for i, id := range tt.listeners[:tt.required] {
+				result, err := p.VoteSkip(id)
+
+				assert.NoError(t, err)
+				assert.Equal(t, i+1, result.Votes)
+				assert.Equal(t, tt.required, result.Required)
+				assert.Equal(t, i+1 == tt.required, result.Skipped)
+			}
+			assert.Equal(t, 1, *skips)
+		})
+	}
+}
+
+func TestGuildPlayer_VoteSkip_RequesterSkipsOwnSong(t *testing.T) {
+	p, skips := newVotingPlayer(0.5, "a", "a", "b", "c", "d")
+
+	result, err := p.VoteSkip("a")
+
+	assert.NoError(t, err)
+	assert.True(t, result.Skipped)
+	assert.Equal(t, 1, *skips)
+}
+
+func TestGuildPlayer_VoteSkip_AlreadyVoted(t *testing.T) {
+	p, skips := newVotingPlayer(1, "otro", "a", "b")
+
+	_, err := p.VoteSkip("a")
+	assert.NoError(t, err)
+	_, err = p.VoteSkip("a")
+
+	assert.ErrorIs(t, err, ErrAlreadyVoted)
+	assert.Equal(t, 0, *skips)
+}
+
+func TestGuildPlayer_VoteSkip_NotListening(t *testing.T) {
+	p, skips := newVotingPlayer(0.5, "otro", "a", "b")
+
+	_, err := p.VoteSkip("c")
+
+	assert.ErrorIs(t, err, ErrNotListening)
+	assert.Equal(t, 0, *skips)
+}
+
+func TestGuildPlayer_VoteSkip_IgnoresVotesOfListenersWhoLeft(t *testing.T) {
+	p, skips := newVotingPlayer(1, "otro", "a", "b", "c")
+
+	_, err := p.VoteSkip("a")
+	assert.NoError(t, err)
+	setListeners(p, "b", "c")
+	result, err := p.VoteSkip("b")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Votes)
+	assert.Equal(t, 2, result.Required)
+	assert.False(t, result.Skipped)
+	assert.Equal(t, 0, *skips)
+}
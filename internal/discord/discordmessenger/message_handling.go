@@ -5,6 +5,8 @@ import (
 	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
 	"github.com/bwmarrin/discordgo"
 	"go.uber.org/zap"
+	"os"
+	"path/filepath"
 )
 
 // MessageSenderWrapper es una interfaz que envuelve los métodos necesarios de discordgo.Session para enviar mensajes.
@@ -31,6 +33,8 @@ type ChatMessageSender interface {
 	SendMessage(channelID, message string) error
 	SendPlayMessage(channelID string, message *voice.PlayMessage) (string, error)
 	EditPlayMessage(channelID, messageID string, message *voice.PlayMessage) error
+	SendEmbed(channelID string, embed *discordgo.MessageEmbed) (string, error)
+	EditEmbed(channelID, messageID string, embed *discordgo.MessageEmbed) error
 }
 
 // MessageSenderImpl implementa la interfaz ChatMessageSender para enviar mensajes en Discord.
@@ -59,13 +63,30 @@ func (session *MessageSenderImpl) SendMessage(channelID, message string) error {
 	return nil
 }
 
-// SendPlayMessage envía un mensaje de reproducción con detalles sobre la canción que se está reproduciendo en el canal de Discord.
+// SendPlayMessage envía un mensaje de reproducción con detalles sobre la canción que se está reproduciendo en el canal de Discord,
+// junto con los botones para controlar la reproducción.
 func (session *MessageSenderImpl) SendPlayMessage(channelID string, message *voice.PlayMessage) (string, error) {
 	session.logger.Info("Enviando mensaje de reproducción...")
+	data := &discordgo.MessageSend{
+		Embed:      voice.GeneratePlayingSongEmbed(message),
+		Components: voice.GeneratePlayerControls(message),
+	}
+	// Las portadas locales se adjuntan al mensaje. Al editarlo los adjuntos se conservan, así que solo se envían acá.
+	if message.Song != nil && message.Song.ThumbnailURL == nil && message.Song.ThumbnailFile != "" {
+		cover, err := os.Open(message.Song.ThumbnailFile)
+		if err != nil {
+			session.logger.Warn("No se pudo abrir la portada de la canción", zap.Error(err))
+		} else {
+			defer cover.Close()
+			data.Files = []*discordgo.File{{
+				Name:        filepath.Base(message.Song.ThumbnailFile),
+				ContentType: "image/jpeg",
+				Reader:      cover,
+			}}
+		}
+	}
 	// Enviar el mensaje de reproducción al canal especificado.
-	msg, err := session.DiscordSession.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
-		Embed: voice.GeneratePlayingSongEmbed(message),
-	})
+	msg, err := session.DiscordSession.ChannelMessageSendComplex(channelID, data)
 	if err != nil {
 		session.logger.Error("Error al enviar mensaje de reproducción: ", zap.Error(err))
 		return "", err
@@ -74,14 +95,19 @@ func (session *MessageSenderImpl) SendPlayMessage(channelID string, message *voi
 }
 
 // EditPlayMessage edita un mensaje de reproducción previamente enviado para actualizar los detalles sobre la canción que se está reproduciendo.
+// Los botones se vuelven a generar para que reflejen el estado actual del reproductor.
 func (session *MessageSenderImpl) EditPlayMessage(channelID string, messageID string, message *voice.PlayMessage) error {
 	// Editar el mensaje de reproducción con los nuevos detalles de la canción.
 	embeds := []*discordgo.MessageEmbed{voice.GeneratePlayingSongEmbed(message)}
-	_, err := session.DiscordSession.ChannelMessageEditComplex(&discordgo.MessageEdit{
+	edit := &discordgo.MessageEdit{
 		ID:      messageID,
 		Channel: channelID,
 		Embeds:  &embeds,
-	})
+	}
+	if components := voice.GeneratePlayerControls(message); components != nil {
+		edit.Components = &components
+	}
+	_, err := session.DiscordSession.ChannelMessageEditComplex(edit)
 	if err != nil {
 		session.logger.Error("Error al editar el mensaje de reproducción: ", zap.Error(err))
 		return err
@@ -89,3 +115,30 @@ func (session *MessageSenderImpl) EditPlayMessage(channelID string, messageID st
 
 	return err
 }
+
+// SendEmbed envía un mensaje embed a un canal específico en Discord y devuelve el ID del mensaje.
+func (session *MessageSenderImpl) SendEmbed(channelID string, embed *discordgo.MessageEmbed) (string, error) {
+	msg, err := session.DiscordSession.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Embed: embed,
+	})
+	if err != nil {
+		session.logger.Error("Error al enviar el mensaje embed: ", zap.Error(err))
+		return "", err
+	}
+	return msg.ID, nil
+}
+
+// EditEmbed reemplaza el embed de un mensaje previamente enviado.
+func (session *MessageSenderImpl) EditEmbed(channelID, messageID string, embed *discordgo.MessageEmbed) error {
+	embeds := []*discordgo.MessageEmbed{embed}
+	_, err := session.DiscordSession.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:      messageID,
+		Channel: channelID,
+		Embeds:  &embeds,
+	})
+	if err != nil {
+		session.logger.Error("Error al editar el mensaje embed: ", zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -81,6 +83,28 @@ func TestSendPlayMessage(t *testing.T) {
 	mockSender.AssertCalled(t, "ChannelMessageSendComplex", channelID, mock.Anything, mock.Anything)
 }
 
+func TestSendPlayMessage_CoverFile(t *testing.T) {
+	// Configuración
+	mockSender := new(MockMessageSender)
+	mockLogger := new(logging.MockLogger)
+	messageSender := NewMessageSenderImpl(mockSender, mockLogger)
+	channelID := "123"
+	coverFile := filepath.Join(t.TempDir(), "portada.jpg")
+	assert.NoError(t, os.WriteFile(coverFile, []byte("jpeg"), 0o644))
+	mockMessage := &voice.PlayMessage{Song: &voice.Song{Title: "Canción de prueba", ThumbnailFile: coverFile}}
+	mockSender.On("ChannelMessageSendComplex", channelID, mock.MatchedBy(func(data *discordgo.MessageSend) bool {
+		return len(data.Files) == 1 && data.Files[0].Name == "portada.jpg" && data.Embed.Thumbnail.URL == "attachment://portada.jpg"
+	}), mock.Anything).Return(&discordgo.Message{}, nil)
+	mockLogger.On("Info", "Enviando mensaje de reproducción...", mock.AnythingOfType("[]zapcore.Field")).Return()
+
+	// Ejecución
+	_, err := messageSender.SendPlayMessage(channelID, mockMessage)
+
+	// Verificación
+	assert.NoError(t, err)
+	mockSender.AssertExpectations(t)
+}
+
 func TestSendPlayMessage_Error(t *testing.T) {
 	// Configuración
 	mockSender := new(MockMessageSender)
@@ -144,3 +168,34 @@ func TestEditPlayMessage_Error(t *testing.T) {
 	assert.EqualError(t, err, expectedErr.Error())
 	mockSender.AssertCalled(t, "ChannelMessageEditComplex", mock.Anything, mock.Anything, mock.Anything)
 }
+
+func TestSendEmbed(t *testing.T) {
+	// Configuración
+	mockSender := new(MockMessageSender)
+	mockLogger := new(logging.MockLogger)
+	messageSender := NewMessageSenderImpl(mockSender, mockLogger)
+	embed := &discordgo.MessageEmbed{Title: "Votación"}
+	mockSender.On("ChannelMessageSendComplex", "123", &discordgo.MessageSend{Embed: embed}, mock.Anything).Return(&discordgo.Message{ID: "456"}, nil)
+
+	// Ejecución
+	messageID, err := messageSender.SendEmbed("123", embed)
+
+	// Verificación
+	assert.NoError(t, err)
+	assert.Equal(t, "456", messageID)
+}
+
+func TestEditEmbed(t *testing.T) {
+	// Configuración
+	mockSender := new(MockMessageSender)
+	mockLogger := new(logging.MockLogger)
+	messageSender := NewMessageSenderImpl(mockSender, mockLogger)
+	mockSender.On("ChannelMessageEditComplex", mock.Anything, mock.Anything).Return(&discordgo.Message{}, nil)
+
+	// Ejecución
+	err := messageSender.EditEmbed("123", "456", &discordgo.MessageEmbed{Title: "Votación"})
+
+	// Verificación
+	assert.NoError(t, err)
+	mockSender.AssertCalled(t, "ChannelMessageEditComplex", mock.Anything, mock.Anything)
+}
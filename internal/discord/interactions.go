@@ -14,6 +14,7 @@ import (
 	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
 	"github.com/Tomas-vilte/GoMusicBot/internal/metrics"
 	"github.com/Tomas-vilte/GoMusicBot/internal/music/fetcher"
+	"github.com/Tomas-vilte/GoMusicBot/internal/music/source"
 	"github.com/Tomas-vilte/GoMusicBot/internal/services/providers"
 	"github.com/Tomas-vilte/GoMusicBot/internal/storage/s3_audio"
 	"github.com/Tomas-vilte/GoMusicBot/internal/utils"
@@ -29,6 +30,7 @@ type GuildID string
 type InteractionHandler struct {
 	guildsPlayers       map[GuildID]*bot.GuildPlayer
 	songLookup          fetcher.SongLooker
+	sources             *source.Registry
 	storage             InteractionStorage
 	cfg                 *config.Config
 	logger              logging.Logger
@@ -41,6 +43,9 @@ type InteractionHandler struct {
 	executorCommand     fetcher.CommandExecutor
 	upload              s3_audio.Uploader
 	presenceNotifier    *observer.VoicePresenceNotifier
+	playerEventCounter  metrics.CustomMetric
+	queueViews          *queueViewStore
+	autocompleteLimiter *autocompleteLimiter
 }
 
 // NewInteractionHandler crea una nueva instancia de InteractionHandler.
@@ -58,6 +63,7 @@ func NewInteractionHandler(responseHandler ResponseHandler, session SessionServi
 	handler := &InteractionHandler{
 		guildsPlayers:       make(map[GuildID]*bot.GuildPlayer),
 		songLookup:          songLooker,
+		sources:             source.NewRegistry(fetcher.NewYoutubeFetcher(logger, manager, youtubeClient, audioCaching, executorCommand, upload)),
 		storage:             storage,
 		cfg:                 cfg,
 		logger:              logger,
@@ -70,6 +76,8 @@ func NewInteractionHandler(responseHandler ResponseHandler, session SessionServi
 		executorCommand:     executorCommand,
 		upload:              upload,
 		presenceNotifier:    presenceNotifier,
+		queueViews:          newQueueViewStore(cfg.QueueViewTTL),
+		autocompleteLimiter: newAutocompleteLimiter(cfg.AutocompleteInterval),
 	}
 	return handler
 }
@@ -80,6 +88,18 @@ func (handler *InteractionHandler) WithLogger(l logging.Logger) *InteractionHand
 	return handler
 }
 
+// WithSongSources establece el registro de fuentes de canciones. Por defecto solo se usa YouTube.
+func (handler *InteractionHandler) WithSongSources(sources *source.Registry) *InteractionHandler {
+	handler.sources = sources
+	return handler
+}
+
+// WithPlayerEventCounter establece la métrica que cuenta los eventos de los reproductores.
+func (handler *InteractionHandler) WithPlayerEventCounter(counter metrics.CustomMetric) *InteractionHandler {
+	handler.playerEventCounter = counter
+	return handler
+}
+
 // Ready se llama cuando el bot está listo para recibir interacciones.
 func (handler *InteractionHandler) Ready(s *discordgo.Session, event *discordgo.Ready) {
 	if err := s.UpdateGameStatus(0, fmt.Sprintf("con tu vieja /%s", handler.cfg.CommandPrefix)); err != nil {
@@ -93,7 +113,11 @@ func (handler *InteractionHandler) GuildCreate(ctx context.Context, s *discordgo
 		return
 	}
 
-	player := handler.setupGuildPlayer(GuildID(event.Guild.ID), s)
+	player, err := handler.setupGuildPlayer(GuildID(event.Guild.ID), s)
+	if err != nil {
+		handler.logger.Error("falló al crear el reproductor del servidor", zap.String("guildID", event.Guild.ID), zap.Error(err))
+		return
+	}
 	handler.guildsPlayers[GuildID(event.Guild.ID)] = player
 	handler.logger.Info("conectado al servidor", zap.String("guildID", event.Guild.ID))
 	go func() {
@@ -121,7 +145,10 @@ func (handler *InteractionHandler) StartPresenceCheck(s *discordgo.Session) {
 func (handler *InteractionHandler) GuildDelete(s *discordgo.Session, event *discordgo.GuildDelete) {
 	guildID := GuildID(event.Guild.ID)
 
-	player := handler.getGuildPlayer(guildID, s)
+	player, ok := handler.guildsPlayers[guildID]
+	if !ok {
+		return
+	}
 	if err := player.Close(); err != nil {
 		handler.logger.Error("Hubo un error al cerrar el reproductor", zap.Error(err))
 	}
@@ -130,6 +157,49 @@ func (handler *InteractionHandler) GuildDelete(s *discordgo.Session, event *disc
 
 // PlaySong maneja el comando de reproducción de una canción.
 func (handler *InteractionHandler) PlaySong(ctx context.Context, s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) {
+	handler.playSong(ctx, s, ic, opt, false)
+}
+
+// PlayNextSong maneja el comando de reproducción de una canción a continuación de la actual.
+func (handler *InteractionHandler) PlayNextSong(ctx context.Context, s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) {
+	handler.playSong(ctx, s, ic, opt, true)
+}
+
+// playSong arma el pedido con las opciones del comando: el texto de input o el archivo adjunto de attachment,
+// que tiene prioridad si se indican los dos.
+func (handler *InteractionHandler) playSong(ctx context.Context, s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption, next bool) {
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(opt.Options))
+	for _, opt := range opt.Options {
+		optionMap[opt.Name] = opt
+	}
+
+	var request songRequest
+	if o, ok := optionMap["input"]; ok {
+		request.input = strings.TrimSpace(o.StringValue())
+	}
+	if o, ok := optionMap["attachment"]; ok {
+		var attachment *discordgo.MessageAttachment
+		if id, ok := o.Value.(string); ok && ic.ApplicationCommandData().Resolved != nil {
+			attachment = ic.ApplicationCommandData().Resolved.Attachments[id]
+		}
+		request.attachments = audioAttachments([]*discordgo.MessageAttachment{attachment})
+		if len(request.attachments) == 0 {
+			handler.respondNoAudioAttachment(ic)
+			return
+		}
+	}
+	if request.input == "" && len(request.attachments) == 0 {
+		if err := handler.responseHandler.RespondWithEphemeralMessage(handler.session, ic.Interaction, ErrorMessageMissingSong); err != nil {
+			handler.logger.Error("falló al responder que falta la canción", zap.Error(err))
+		}
+		return
+	}
+
+	handler.queueSongs(ctx, s, ic, request, next)
+}
+
+// queueSongs busca las canciones del pedido y las agrega al final de la lista de reproducción, o al principio si next es true.
+func (handler *InteractionHandler) queueSongs(ctx context.Context, s *discordgo.Session, ic *discordgo.InteractionCreate, request songRequest, next bool) {
 	handler.logger.With(zap.String("guildID", ic.GuildID))
 	g, err := s.State.Guild(ic.GuildID)
 	if err != nil {
@@ -139,14 +209,20 @@ func (handler *InteractionHandler) PlaySong(ctx context.Context, s *discordgo.Se
 		}
 		return
 	}
-	handler.commandUsageCounter.Inc("PlaySong")
-	player := handler.getGuildPlayer(GuildID(g.ID), s)
-	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(opt.Options))
-	for _, opt := range opt.Options {
-		optionMap[opt.Name] = opt
+	if next {
+		handler.commandUsageCounter.Inc("PlayNextSong")
+	} else {
+		handler.commandUsageCounter.Inc("PlaySong")
 	}
-
-	input := optionMap["input"].StringValue()
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
+	addSong := player.AddSong
+	if next {
+		addSong = player.AddSongNext
+	}
+	input := request.name()
 	channelID := ic.ChannelID
 	handler.getVoiceChannelMembers(s, channelID)
 
@@ -167,18 +243,7 @@ func (handler *InteractionHandler) PlaySong(ctx context.Context, s *discordgo.Se
 	}
 
 	go func(ic *discordgo.InteractionCreate, vs *discordgo.VoiceState) {
-		videoID, err := handler.songLookup.SearchYouTubeVideoID(ctx, input)
-		if err != nil {
-			handler.logger.Error("Error al buscar el ID del video en YouTube", zap.Error(err), zap.String("input", input))
-			if err := handler.responseHandler.CreateFollowupMessage(handler.session, ic.Interaction, discordgo.WebhookParams{
-				Embeds: []*discordgo.MessageEmbed{GenerateFailedToAddSongEmbed(input, ic.Member)},
-			}); err != nil {
-				handler.logger.Error("falló al enviar el mensaje de seguimiento de error al buscar el ID del video", zap.Error(err))
-			}
-			return
-		}
-
-		songs, err := handler.songLookup.LookupSongs(ctx, videoID)
+		songs, err := handler.resolveRequest(ctx, request)
 		if err != nil {
 			handler.logger.Info("falló al buscar la metadata de la canción", zap.Error(err), zap.String("input", input))
 			if err := handler.responseHandler.CreateFollowupMessage(handler.session, ic.Interaction, discordgo.WebhookParams{
@@ -189,10 +254,7 @@ func (handler *InteractionHandler) PlaySong(ctx context.Context, s *discordgo.Se
 			return
 		}
 
-		memberName := getMemberName(ic.Member)
-		for i := range songs {
-			songs[i].RequestedBy = &memberName
-		}
+		songs = request.assignRequester(songs, ic.Member)
 
 		if len(songs) == 0 {
 			if err := handler.responseHandler.CreateFollowupMessage(handler.session, ic.Interaction, discordgo.WebhookParams{
@@ -205,7 +267,17 @@ func (handler *InteractionHandler) PlaySong(ctx context.Context, s *discordgo.Se
 
 		if len(songs) == 1 {
 			song := songs[0]
-			if err := player.AddSong(&ic.ChannelID, &vs.ChannelID, song); err != nil {
+			result, err := addSong(&ic.ChannelID, &vs.ChannelID, song)
+			if errors.Is(err, bot.ErrRequesterLimit) {
+				if err := handler.responseHandler.CreateFollowupMessage(handler.session, ic.Interaction, discordgo.WebhookParams{
+					Content: ErrorMessageRequesterLimit,
+					Embeds:  []*discordgo.MessageEmbed{GenerateFailedToAddSongEmbed(input, ic.Member)},
+				}); err != nil {
+					handler.logger.Error("falló al enviar el mensaje de seguimiento de límite alcanzado", zap.Error(err))
+				}
+				return
+			}
+			if err != nil {
 				handler.logger.Info("falló al agregar la canción", zap.Error(err), zap.String("input", input))
 				if err := handler.responseHandler.CreateFollowupMessage(handler.session, ic.Interaction, discordgo.WebhookParams{
 					Embeds: []*discordgo.MessageEmbed{GenerateFailedToAddSongEmbed(input, ic.Member)},
@@ -215,22 +287,37 @@ func (handler *InteractionHandler) PlaySong(ctx context.Context, s *discordgo.Se
 				return
 			}
 			if err := handler.responseHandler.CreateFollowupMessage(handler.session, ic.Interaction, discordgo.WebhookParams{
-				Embeds: []*discordgo.MessageEmbed{GenerateAddedSongEmbed(song, ic.Member)},
+				Embeds: []*discordgo.MessageEmbed{GenerateAddedSongEmbed(song, ic.Member, result)},
 			}); err != nil {
 				handler.logger.Error("falló al enviar el mensaje de seguimiento de canción agregada", zap.Error(err))
 			}
 			return
 		}
 
+		if len(request.attachments) > 0 {
+			// Los archivos adjuntos se agregan todos, porque no son una lista de reproducción de la que elegir una canción.
+			result, err := addSong(&ic.ChannelID, &vs.ChannelID, songs...)
+			if err := handler.responseHandler.CreateFollowupMessage(handler.session, ic.Interaction, discordgo.WebhookParams{
+				Content: handler.addedSongsMessage(result, err, len(songs)),
+			}); err != nil {
+				handler.logger.Error("falló al enviar el mensaje de seguimiento de canciones agregadas", zap.Error(err))
+			}
+			return
+		}
+
 		handler.storage.SaveSongList(ic.ChannelID, songs)
 
+		customID := "add_song_playlist"
+		if next {
+			customID = "add_song_playlist_next"
+		}
 		if err := handler.responseHandler.CreateFollowupMessage(handler.session, ic.Interaction, discordgo.WebhookParams{
 			Embeds: []*discordgo.MessageEmbed{GenerateAskAddPlaylistEmbed(songs, ic.Member)},
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
 					Components: []discordgo.MessageComponent{
 						discordgo.SelectMenu{
-							CustomID: "add_song_playlist",
+							CustomID: customID,
 							Options: []discordgo.SelectMenuOption{
 								{Label: "Agregar canción", Value: "song", Emoji: &discordgo.ComponentEmoji{Name: "🎵"}},
 								{Label: "Agregar lista de reproducción completa", Value: "playlist", Emoji: &discordgo.ComponentEmoji{Name: "🎶"}},
@@ -273,7 +360,14 @@ func (handler *InteractionHandler) AddSongOrPlaylist(s *discordgo.Session, ic *d
 		return
 	}
 
-	player := handler.getGuildPlayer(GuildID(g.ID), s)
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
+	addSong := player.AddSong
+	if ic.MessageComponentData().CustomID == "add_song_playlist_next" {
+		addSong = player.AddSongNext
+	}
 
 	var voiceChannelID *string = nil
 
@@ -293,17 +387,18 @@ func (handler *InteractionHandler) AddSongOrPlaylist(s *discordgo.Session, ic *d
 
 	switch value {
 	case "playlist":
-		for _, song := range songs {
-			if err := player.AddSong(&ic.Message.ChannelID, voiceChannelID, song); err != nil {
-				handler.logger.Info("falló al agregar la canción", zap.Error(err), zap.String("input", song.URL))
-			}
-		}
-		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, fmt.Sprintf("➕ Se añadieron %d canciones a la lista de reproducción", len(songs))); err != nil {
+		result, err := addSong(&ic.Message.ChannelID, voiceChannelID, songs...)
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, handler.addedSongsMessage(result, err, len(songs))); err != nil {
 			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
 		}
 	default:
 		song := songs[0]
-		if err := player.AddSong(&ic.Message.ChannelID, voiceChannelID, song); err != nil {
+		result, err := addSong(&ic.Message.ChannelID, voiceChannelID, song)
+		if errors.Is(err, bot.ErrRequesterLimit) {
+			if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, ErrorMessageRequesterLimit); err != nil {
+				handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+			}
+		} else if err != nil {
 			handler.logger.Info("falló al agregar la canción", zap.Error(err), zap.String("input", song.URL))
 			if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, ErrorMessageFailedToAddSong); err != nil {
 				handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
@@ -325,6 +420,9 @@ func (handler *InteractionHandler) AddSongOrPlaylist(s *discordgo.Session, ic *d
 					},
 				},
 			}
+			if field := GenerateRequesterQuotaField(result); field != nil {
+				embed.Fields = append(embed.Fields, field)
+			}
 
 			if song.ThumbnailURL != nil {
 				embed.Thumbnail = &discordgo.MessageEmbedThumbnail{
@@ -345,6 +443,22 @@ func (handler *InteractionHandler) AddSongOrPlaylist(s *discordgo.Session, ic *d
 	handler.storage.DeleteSongList(ic.ChannelID)
 }
 
+// addedSongsMessage arma la respuesta al agregar varias canciones a la vez, con el error de addSong si falló.
+func (handler *InteractionHandler) addedSongsMessage(result *bot.QueueResult, err error, count int) string {
+	switch {
+	case errors.Is(err, bot.ErrRequesterLimit):
+		return ErrorMessageRequesterLimit
+	case err != nil:
+		handler.logger.Info("falló al agregar las canciones", zap.Error(err), zap.Int("cantidad", count))
+		return ErrorMessageFailedToAddSong
+	}
+	message := fmt.Sprintf("➕ Se añadieron %d canciones a la lista de reproducción", len(result.Added))
+	if field := GenerateRequesterQuotaField(result); field != nil {
+		message = fmt.Sprintf("%s\n**%s:** %s", message, field.Name, field.Value)
+	}
+	return message
+}
+
 // StopPlaying detiene la reproducción de música.
 func (handler *InteractionHandler) StopPlaying(s *discordgo.Session, ic *discordgo.InteractionCreate, acido *discordgo.ApplicationCommandInteractionDataOption) {
 	g, err := s.State.Guild(ic.GuildID)
@@ -356,7 +470,10 @@ func (handler *InteractionHandler) StopPlaying(s *discordgo.Session, ic *discord
 		return
 	}
 
-	player := handler.getGuildPlayer(GuildID(g.ID), s)
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
 	handler.commandUsageCounter.Inc("StopPlaying")
 	if err := player.Stop(); err != nil {
 		handler.logger.Info("falló al detener la reproducción", zap.Error(err))
@@ -381,16 +498,52 @@ func (handler *InteractionHandler) SkipSong(s *discordgo.Session, ic *discordgo.
 		return
 	}
 
-	player := handler.getGuildPlayer(GuildID(g.ID), s)
-	player.SkipSong()
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
 	handler.commandUsageCounter.Inc("SkipSong")
-	if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "⏭️ Canción omitida"); err != nil {
+
+	var result *bot.SkipVoteResult
+	if skipsWithoutVote(ic.Member, config.GetGuildPermissions(handler.cfg, ic.GuildID)) {
+		err = player.SkipSong()
+		result = &bot.SkipVoteResult{Votes: 1, Required: 1, Skipped: true}
+	} else {
+		result, err = player.VoteSkip(ic.Member.User.ID)
+	}
+	if err != nil {
+		var message string
+		switch {
+		case errors.Is(err, bot.ErrNotPlaying):
+			message = "🔇 No se está reproduciendo ninguna canción en este momento..."
+		case errors.Is(err, bot.ErrNotListening):
+			message = "🎧 Tenés que estar en el canal de voz para votar"
+		case errors.Is(err, bot.ErrAlreadyVoted):
+			message = "🗳️ Ya votaste para saltar esta canción"
+		default:
+			handler.logger.Error("falló al votar para saltar la canción", zap.Error(err))
+			message = "Ocurrió un error al saltar la canción"
+		}
+		if err := handler.responseHandler.RespondWithEphemeralMessage(handler.session, ic.Interaction, message); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	if result.Skipped {
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "⏭️ Canción omitida"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+	message := fmt.Sprintf("🗳️ Voto registrado (%d/%d)", result.Votes, result.Required)
+	if err := handler.responseHandler.RespondWithEphemeralMessage(handler.session, ic.Interaction, message); err != nil {
 		handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
 	}
 }
 
-// ListPlaylist lista las canciones en la lista de reproducción actual.
-func (handler *InteractionHandler) ListPlaylist(s *discordgo.Session, ic *discordgo.InteractionCreate, acido *discordgo.ApplicationCommandInteractionDataOption) {
+// PauseSong pausa la canción actualmente en reproducción.
+func (handler *InteractionHandler) PauseSong(s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) {
 	g, err := s.State.Guild(ic.GuildID)
 	if err != nil {
 		handler.logger.Info("falló al obtener el servidor", zap.Error(err))
@@ -400,44 +553,415 @@ func (handler *InteractionHandler) ListPlaylist(s *discordgo.Session, ic *discor
 		return
 	}
 
-	player := handler.getGuildPlayer(GuildID(g.ID), s)
-	handler.commandUsageCounter.Inc("ListPlaylist")
-	playlist, err := player.GetPlaylist()
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
+	handler.commandUsageCounter.Inc("PauseSong")
+	message := "⏸️ Reproducción pausada"
+	if err := player.Pause(); err != nil {
+		switch {
+		case errors.Is(err, bot.ErrNotPlaying):
+			message = "🔇 No se está reproduciendo ninguna canción en este momento..."
+		case errors.Is(err, bot.ErrAlreadyPaused):
+			message = "⏸️ La reproducción ya está en pausa"
+		default:
+			handler.logger.Error("falló al pausar la reproducción", zap.Error(err))
+			message = "Ocurrió un error al pausar la reproducción"
+		}
+	}
+
+	if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, message); err != nil {
+		handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+	}
+}
+
+// ResumeSong reanuda la canción pausada.
+func (handler *InteractionHandler) ResumeSong(s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) {
+	g, err := s.State.Guild(ic.GuildID)
 	if err != nil {
-		handler.logger.Error("falló al obtener la lista de reproducción", zap.Error(err))
+		handler.logger.Info("falló al obtener el servidor", zap.Error(err))
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al obtener la información del servidor"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
 		return
 	}
 
-	if len(playlist) == 0 {
-		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "🫙 La lista de reproducción está vacía"); err != nil {
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
+	handler.commandUsageCounter.Inc("ResumeSong")
+	message := "▶️ Reproducción reanudada"
+	if err := player.Resume(); err != nil {
+		switch {
+		case errors.Is(err, bot.ErrNotPlaying):
+			message = "🔇 No se está reproduciendo ninguna canción en este momento..."
+		case errors.Is(err, bot.ErrNotPaused):
+			message = "▶️ La reproducción no está en pausa"
+		default:
+			handler.logger.Error("falló al reanudar la reproducción", zap.Error(err))
+			message = "Ocurrió un error al reanudar la reproducción"
+		}
+	}
+
+	if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, message); err != nil {
+		handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+	}
+}
+
+// SeekSong reinicia la canción actual en la posición indicada.
+func (handler *InteractionHandler) SeekSong(s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) {
+	g, err := s.State.Guild(ic.GuildID)
+	if err != nil {
+		handler.logger.Info("falló al obtener el servidor", zap.Error(err))
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al obtener la información del servidor"); err != nil {
 			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
 		}
-	} else {
-		builder := strings.Builder{}
+		return
+	}
 
-		for idx, song := range playlist {
-			line := fmt.Sprintf("%d. %s\n", idx+1, song)
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
+	handler.commandUsageCounter.Inc("SeekSong")
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(opt.Options))
+	for _, opt := range opt.Options {
+		optionMap[opt.Name] = opt
+	}
 
-			if len(line)+builder.Len() > 4000 {
-				builder.WriteString("...")
-				break
+	input := optionMap["position"].StringValue()
+	position, err := utils.ParseTimestamp(input)
+	if err != nil {
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "🤷🏽 Formato de posición no válido, usá mm:ss"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	message := fmt.Sprintf("⏩ Saltando a %s", utils.FmtDuration(position))
+	if err := player.Seek(position); err != nil {
+		switch {
+		case errors.Is(err, bot.ErrNotPlaying):
+			message = "🔇 No se está reproduciendo ninguna canción en este momento..."
+		case errors.Is(err, bot.ErrInvalidSeekPosition):
+			message = "🤷🏽 Posición no válida"
+		case errors.Is(err, bot.ErrSeekLive):
+			message = "🔴 No se puede saltar en una transmisión en vivo"
+		default:
+			handler.logger.Error("falló al reposicionar la canción", zap.Error(err))
+			message = "Ocurrió un error al reposicionar la canción"
+		}
+	}
+
+	if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, message); err != nil {
+		handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+	}
+}
+
+// SetLoopMode cambia el modo de repetición del reproductor.
+func (handler *InteractionHandler) SetLoopMode(s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) {
+	g, err := s.State.Guild(ic.GuildID)
+	if err != nil {
+		handler.logger.Info("falló al obtener el servidor", zap.Error(err))
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al obtener la información del servidor"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
+	handler.commandUsageCounter.Inc("SetLoopMode")
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(opt.Options))
+	for _, opt := range opt.Options {
+		optionMap[opt.Name] = opt
+	}
+
+	mode := voice.LoopMode(optionMap["mode"].StringValue())
+	var message string
+	switch mode {
+	case voice.LoopModeTrack:
+		message = "🔂 Repitiendo la canción actual"
+	case voice.LoopModeQueue:
+		message = "🔁 Repitiendo la lista de reproducción"
+	default:
+		message = "➡️ Repetición desactivada"
+	}
+
+	if err := player.SetLoopMode(mode); err != nil {
+		if errors.Is(err, bot.ErrInvalidLoopMode) {
+			message = "🤷🏽 Modo de repetición no válido"
+		} else {
+			handler.logger.Error("falló al establecer el modo de repetición", zap.Error(err))
+			message = "Ocurrió un error al establecer el modo de repetición"
+		}
+	}
+
+	if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, message); err != nil {
+		handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+	}
+}
+
+// SetVolume cambia el volumen de reproducción del servidor.
+func (handler *InteractionHandler) SetVolume(s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) {
+	g, err := s.State.Guild(ic.GuildID)
+	if err != nil {
+		handler.logger.Info("falló al obtener el servidor", zap.Error(err))
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al obtener la información del servidor"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
+	handler.commandUsageCounter.Inc("SetVolume")
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(opt.Options))
+	for _, opt := range opt.Options {
+		optionMap[opt.Name] = opt
+	}
+
+	volume := int(optionMap["level"].IntValue())
+	message := fmt.Sprintf("🔊 Volumen establecido en %d%%", volume)
+	if err := player.SetVolume(volume); err != nil {
+		if errors.Is(err, bot.ErrInvalidVolume) {
+			message = fmt.Sprintf("🤷🏽 El volumen debe estar entre %d y %d", voice.MinVolume, voice.MaxVolume)
+		} else {
+			handler.logger.Error("falló al establecer el volumen", zap.Error(err))
+			message = "Ocurrió un error al establecer el volumen"
+		}
+	}
+
+	if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, message); err != nil {
+		handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+	}
+}
+
+// SetAutoplay activa o desactiva la reproducción automática del servidor.
+func (handler *InteractionHandler) SetAutoplay(s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) {
+	g, err := s.State.Guild(ic.GuildID)
+	if err != nil {
+		handler.logger.Info("falló al obtener el servidor", zap.Error(err))
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al obtener la información del servidor"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
+	handler.commandUsageCounter.Inc("SetAutoplay")
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(opt.Options))
+	for _, opt := range opt.Options {
+		optionMap[opt.Name] = opt
+	}
+
+	enabled := optionMap["enabled"].BoolValue()
+	message := "📻 Reproducción automática activada"
+	if !enabled {
+		message = "⏹️ Reproducción automática desactivada"
+	}
+
+	if err := player.SetAutoplay(enabled); err != nil {
+		handler.logger.Error("falló al establecer la reproducción automática", zap.Error(err))
+		message = "Ocurrió un error al establecer la reproducción automática"
+	}
+
+	if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, message); err != nil {
+		handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+	}
+}
+
+// ShufflePlaylist mezcla aleatoriamente la lista de reproducción.
+func (handler *InteractionHandler) ShufflePlaylist(s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) {
+	g, err := s.State.Guild(ic.GuildID)
+	if err != nil {
+		handler.logger.Info("falló al obtener el servidor", zap.Error(err))
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al obtener la información del servidor"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
+	handler.commandUsageCounter.Inc("ShufflePlaylist")
+	if err := player.ShufflePlaylist(); err != nil {
+		handler.logger.Error("falló al mezclar la lista de reproducción", zap.Error(err))
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al mezclar la lista de reproducción"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "🔀 Lista de reproducción mezclada"); err != nil {
+		handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+	}
+}
+
+// MoveSong mueve una canción de la lista de reproducción a otra posición.
+func (handler *InteractionHandler) MoveSong(s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) {
+	g, err := s.State.Guild(ic.GuildID)
+	if err != nil {
+		handler.logger.Info("falló al obtener el servidor", zap.Error(err))
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al obtener la información del servidor"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
+	handler.commandUsageCounter.Inc("MoveSong")
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(opt.Options))
+	for _, opt := range opt.Options {
+		optionMap[opt.Name] = opt
+	}
+
+	from := optionMap["from"].IntValue()
+	to := optionMap["to"].IntValue()
+
+	song, err := player.MoveSong(int(from), int(to))
+	if err != nil {
+		if errors.Is(err, bot.ErrInvalidPosition) {
+			if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "🤷🏽 Posición no válida"); err != nil {
+				handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
 			}
+			return
+		}
 
-			builder.WriteString(fmt.Sprintf("%d. %s\n", idx+1, song))
+		handler.logger.Error("falló al mover la canción", zap.Error(err))
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al mover la cancion"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
 		}
+		return
+	}
 
-		message := strings.TrimSpace(builder.String())
+	if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, fmt.Sprintf("↕️ Canción **%v** movida a la posición %d", song.GetHumanName(), to)); err != nil {
+		handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+	}
+}
 
-		if err := handler.responseHandler.Respond(handler.session, ic.Interaction, discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Embeds: []*discordgo.MessageEmbed{
-					{Title: "Lista de reproducción:", Description: message},
-				},
+// ListHistory lista las canciones reproducidas recientemente.
+func (handler *InteractionHandler) ListHistory(s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) {
+	g, err := s.State.Guild(ic.GuildID)
+	if err != nil {
+		handler.logger.Info("falló al obtener el servidor", zap.Error(err))
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al obtener la información del servidor"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
+	handler.commandUsageCounter.Inc("ListHistory")
+	history, err := player.GetHistory()
+	if err != nil {
+		handler.logger.Error("falló al obtener el historial", zap.Error(err))
+		return
+	}
+
+	if len(history) == 0 {
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "🫙 Todavía no se reprodujo ninguna canción"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	builder := strings.Builder{}
+	for idx, song := range history {
+		line := fmt.Sprintf("%d. %s", idx+1, song.GetHumanName())
+		if song.RequestedBy != nil {
+			line += fmt.Sprintf(" — %s", *song.RequestedBy)
+		} else if song.Autoplay {
+			line += " — 📻 reproducción automática"
+		}
+		line += "\n"
+
+		if len(line)+builder.Len() > 4000 {
+			builder.WriteString("...")
+			break
+		}
+
+		builder.WriteString(line)
+	}
+
+	if err := handler.responseHandler.Respond(handler.session, ic.Interaction, discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{
+				{Title: "Historial de reproducción:", Description: strings.TrimSpace(builder.String())},
 			},
-		}); err != nil {
+		},
+	}); err != nil {
+		handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+	}
+}
+
+// PlayPrevious vuelve a agregar la última canción reproducida al principio de la lista de reproducción.
+func (handler *InteractionHandler) PlayPrevious(s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) {
+	g, err := s.State.Guild(ic.GuildID)
+	if err != nil {
+		handler.logger.Info("falló al obtener el servidor", zap.Error(err))
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al obtener la información del servidor"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
+	handler.commandUsageCounter.Inc("PlayPrevious")
+
+	vs := getUsersVoiceState(g, ic.Member.User)
+	if vs == nil {
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, ErrorMessageNotInVoiceChannel); err != nil {
+			handler.logger.Error("falló al responder con el error de no estar en un canal de voz", zap.Error(err))
+		}
+		return
+	}
+
+	song, err := player.PlayPrevious(&ic.ChannelID, &vs.ChannelID)
+	if err != nil {
+		if errors.Is(err, bot.ErrEmptyHistory) {
+			if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "🫙 Todavía no se reprodujo ninguna canción"); err != nil {
+				handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+			}
+			return
+		}
+		if errors.Is(err, bot.ErrRequesterLimit) {
+			if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, ErrorMessageRequesterLimit); err != nil {
+				handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+			}
+			return
+		}
+
+		handler.logger.Error("falló al agregar la canción anterior", zap.Error(err))
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al agregar la canción anterior"); err != nil {
 			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
 		}
+		return
+	}
+
+	if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, fmt.Sprintf("⏮️ **%v** vuelve a sonar a continuación", song.GetHumanName())); err != nil {
+		handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
 	}
 }
 
@@ -452,7 +976,10 @@ func (handler *InteractionHandler) RemoveSong(s *discordgo.Session, ic *discordg
 		return
 	}
 
-	player := handler.getGuildPlayer(GuildID(g.ID), s)
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
 	handler.commandUsageCounter.Inc("RemoveSong")
 	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(opt.Options))
 	for _, opt := range opt.Options {
@@ -461,7 +988,12 @@ func (handler *InteractionHandler) RemoveSong(s *discordgo.Session, ic *discordg
 
 	position := optionMap["position"].IntValue()
 
-	song, err := player.RemoveSong(int(position))
+	var song *voice.Song
+	if removesOwnSongsOnly(ic.Member, config.GetGuildPermissions(handler.cfg, ic.GuildID)) {
+		song, err = player.RemoveRequesterSong(int(position), ic.Member.User.ID)
+	} else {
+		song, err = player.RemoveSong(int(position))
+	}
 	if err != nil {
 		if errors.Is(err, bot.ErrRemoveInvalidPosition) {
 			if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "🤷🏽 Posición no válida"); err != nil {
@@ -469,6 +1001,12 @@ func (handler *InteractionHandler) RemoveSong(s *discordgo.Session, ic *discordg
 			}
 			return
 		}
+		if errors.Is(err, bot.ErrNotSongOwner) {
+			if err := handler.responseHandler.RespondWithEphemeralMessage(handler.session, ic.Interaction, ErrorMessageRemoveNotOwnSong); err != nil {
+				handler.logger.Error("falló al responder con el mensaje de permiso denegado", zap.Error(err))
+			}
+			return
+		}
 
 		handler.logger.Error("falló al eliminar la canción", zap.Error(err))
 		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al eliminar la cancion"); err != nil {
@@ -493,7 +1031,10 @@ func (handler *InteractionHandler) GetPlayingSong(s *discordgo.Session, ic *disc
 		return
 	}
 
-	player := handler.getGuildPlayer(GuildID(g.ID), s)
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
 	handler.commandUsageCounter.Inc("GetPlayingSong")
 	song, err := player.GetPlayedSong()
 	if err != nil {
@@ -511,31 +1052,78 @@ func (handler *InteractionHandler) GetPlayingSong(s *discordgo.Session, ic *disc
 		return
 	}
 
-	if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, fmt.Sprintf("🎶 %s", song.GetHumanName())); err != nil {
+	message := fmt.Sprintf("🎶 %s", song.GetHumanName())
+	if song.StreamTitle != "" {
+		message = fmt.Sprintf("%s\n🎙️ %s", message, song.StreamTitle)
+	}
+	if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, message); err != nil {
 		handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
 	}
 }
 
 // setupGuildPlayer configura un reproductor para un servidor dado.
-func (handler *InteractionHandler) setupGuildPlayer(guildID GuildID, dg *discordgo.Session) *bot.GuildPlayer {
+func (handler *InteractionHandler) setupGuildPlayer(guildID GuildID, dg *discordgo.Session) (*bot.GuildPlayer, error) {
 	dca := codec.NewDCAStreamerImpl(handler.logger)
 	voiceChat := voice.NewChatSessionImpl(dg, string(guildID), dca, handler.logger)
 	messageSender := discordmessenger.NewMessageSenderImpl(dg, handler.logger)
-	fetcherGetDCA := fetcher.NewYoutubeFetcher(handler.logger, handler.caching, handler.realYoutubeClient, handler.audioCaching, handler.executorCommand, handler.upload)
-	songStorage, stateStorage := config.GetPlaylistStore(handler.cfg, string(guildID), handler.logger)
-	player := bot.NewGuildPlayer(voiceChat, songStorage, stateStorage, fetcherGetDCA.GetDCAData, messageSender, handler.logger)
-	return player
+	relatedSongs := fetcher.NewYoutubeFetcher(handler.logger, handler.caching, handler.realYoutubeClient, handler.audioCaching, handler.executorCommand, handler.upload)
+	songStorage, stateStorage, err := config.GetPlaylistStore(handler.cfg, string(guildID), handler.logger)
+	if err != nil {
+		return nil, fmt.Errorf("al abrir el store del servidor: %w", err)
+	}
+	historyStorage := config.GetHistoryStore(handler.cfg, string(guildID), handler.logger)
+	// El audio de cada canción lo produce la fuente de la que salió, según su tipo.
+	player := bot.NewGuildPlayer(voiceChat, songStorage, stateStorage, historyStorage, handler.sources.GetDCAData, messageSender, handler.logger).
+		WithRelatedSongGetter(relatedSongs.GetRelatedSong).
+		WithPrefetcher(handler.sources.Prefetch, handler.cfg.PrefetchThreshold).
+		WithIdlePolicy(bot.IdlePolicy{
+			QueueEmptyTimeout: handler.cfg.IdleTimeout,
+			AloneGracePeriod:  handler.cfg.AloneGracePeriod,
+		}).
+		WithVoteSkip(handler.cfg.VoteSkipRatio).
+		WithRequesterLimits(bot.RequesterLimits{
+			MaxSongs:    handler.cfg.MaxSongsPerUser,
+			MaxDuration: handler.cfg.MaxQueuedDurationPerUser,
+		}).
+		WithMaxConsecutiveFailures(handler.cfg.MaxConsecutiveFailures)
+
+	if handler.playerEventCounter != nil {
+		events, _ := player.Subscribe(64)
+		go func() {
+			for event := range events {
+				handler.playerEventCounter.Inc(event.EventName())
+			}
+		}()
+	}
+	return player, nil
 }
 
 // getGuildPlayer obtiene un reproductor para un servidor dado.
-func (handler *InteractionHandler) getGuildPlayer(guildID GuildID, dg *discordgo.Session) *bot.GuildPlayer {
+func (handler *InteractionHandler) getGuildPlayer(guildID GuildID, dg *discordgo.Session) (*bot.GuildPlayer, error) {
 	player, ok := handler.guildsPlayers[guildID]
 	if !ok {
-		player = handler.setupGuildPlayer(guildID, dg)
+		var err error
+		if player, err = handler.setupGuildPlayer(guildID, dg); err != nil {
+			return nil, err
+		}
 		handler.guildsPlayers[guildID] = player
 	}
 
-	return player
+	return player, nil
+}
+
+// getInteractionPlayer obtiene el reproductor del servidor de la interacción. Si no se puede crear, por ejemplo
+// porque no se pudo abrir su store, responde con un error y devuelve false.
+func (handler *InteractionHandler) getInteractionPlayer(guildID GuildID, s *discordgo.Session, ic *discordgo.InteractionCreate) (*bot.GuildPlayer, bool) {
+	player, err := handler.getGuildPlayer(guildID, s)
+	if err != nil {
+		handler.logger.Error("falló al crear el reproductor del servidor", zap.String("guildID", string(guildID)), zap.Error(err))
+		if err := handler.responseHandler.RespondWithEphemeralMessage(handler.session, ic.Interaction, ErrorMessagePlayerUnavailable); err != nil {
+			handler.logger.Error("falló al responder con el error del reproductor", zap.Error(err))
+		}
+		return nil, false
+	}
+	return player, true
 }
 
 // getUsersVoiceState obtiene el estado de voz de un usuario en un servidor dado.
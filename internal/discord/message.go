@@ -2,14 +2,23 @@ package discord
 
 import (
 	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot"
 	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
 	"github.com/Tomas-vilte/GoMusicBot/internal/utils"
 	"github.com/bwmarrin/discordgo"
+	"strings"
 )
 
 const (
 	ErrorMessageNotInVoiceChannel = "No estas en un canal de voz down. Tenes que unirte a uno para reproducir musica loco"
 	ErrorMessageFailedToAddSong   = "No se pudo agregar la cancion kkkk"
+	ErrorMessageCommandNotAllowed = "🚫 No tenés permiso para usar `/%s %s`"
+	ErrorMessageRemoveNotOwnSong  = "🚫 Solo podés eliminar las canciones que agregaste vos"
+	ErrorMessageRequesterLimit    = "🚫 Alcanzaste tu límite de canciones en la lista de reproducción"
+	ErrorMessageSearchNotOwner    = "🚫 Solo quien hizo la búsqueda puede elegir el resultado"
+	ErrorMessageMissingSong       = "🤷🏽 Indicá una canción o un archivo de audio"
+	ErrorMessageNoAudioAttachment = "🤷🏽 No encontré archivos de audio para reproducir"
+	ErrorMessagePlayerUnavailable = "⚠️ No se pudo preparar el reproductor del servidor, probá de nuevo en un rato"
 )
 
 func GenerateAddingSongEmbed(input string, member *discordgo.Member) *discordgo.MessageEmbed {
@@ -29,7 +38,7 @@ func GenerateAskAddPlaylistEmbed(songs []*voice.Song, requestor *discordgo.Membe
 	return generateAddingSongEmbed(title, "", requestor)
 }
 
-func GenerateAddedSongEmbed(song *voice.Song, member *discordgo.Member) *discordgo.MessageEmbed {
+func GenerateAddedSongEmbed(song *voice.Song, member *discordgo.Member, result *bot.QueueResult) *discordgo.MessageEmbed {
 	embed := generateAddingSongEmbed(song.GetHumanName(), "🎵  Agregado a la cola.", member)
 	embed.Fields = []*discordgo.MessageEmbedField{
 		{
@@ -37,6 +46,9 @@ func GenerateAddedSongEmbed(song *voice.Song, member *discordgo.Member) *discord
 			Value: utils.FmtDuration(song.Duration),
 		},
 	}
+	if field := GenerateRequesterQuotaField(result); field != nil {
+		embed.Fields = append(embed.Fields, field)
+	}
 
 	if song.ThumbnailURL != nil {
 		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{
@@ -47,6 +59,30 @@ func GenerateAddedSongEmbed(song *voice.Song, member *discordgo.Member) *discord
 	return embed
 }
 
+// GenerateRequesterQuotaField genera el campo con lo que ocupa el solicitante en la lista de reproducción respecto de sus límites.
+// Devuelve nil si no hay límites configurados ni canciones descartadas.
+func GenerateRequesterQuotaField(result *bot.QueueResult) *discordgo.MessageEmbedField {
+	if result == nil || (result.Limits.MaxSongs <= 0 && result.Limits.MaxDuration <= 0 && result.Rejected == 0) {
+		return nil
+	}
+
+	var usage []string
+	if result.Limits.MaxSongs > 0 {
+		usage = append(usage, fmt.Sprintf("%d/%d canciones", result.Songs, result.Limits.MaxSongs))
+	}
+	if result.Limits.MaxDuration > 0 {
+		usage = append(usage, fmt.Sprintf("%s/%s", utils.FmtDuration(result.Duration), utils.FmtDuration(result.Limits.MaxDuration)))
+	}
+	value := strings.Join(usage, " · ")
+	if result.Rejected > 0 {
+		value = strings.TrimSpace(fmt.Sprintf("%s\n⚠️ %d canciones descartadas por superar tu límite", value, result.Rejected))
+	}
+	return &discordgo.MessageEmbedField{
+		Name:  "Tu cola",
+		Value: value,
+	}
+}
+
 func generateAddingSongEmbed(title, description string, requestor *discordgo.Member) *discordgo.MessageEmbed {
 	embed := &discordgo.MessageEmbed{
 		Title:       title,
@@ -0,0 +1,81 @@
+package discord
+
+import (
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/config"
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// Authorize verifica que el miembro pueda usar el subcomando según los permisos del servidor.
+// Si no puede, responde con un mensaje visible solo para él y devuelve false.
+func (handler *InteractionHandler) Authorize(s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) bool {
+	permissions := config.GetGuildPermissions(handler.cfg, ic.GuildID)
+
+	// Quién agregó la canción a eliminar lo verifica RemoveSong al eliminarla, para que la posición no cambie en el medio.
+	if canUseCommand(ic.Member, permissions, opt.Name) {
+		return true
+	}
+
+	message := fmt.Sprintf(ErrorMessageCommandNotAllowed, handler.cfg.CommandPrefix, opt.Name)
+	handler.logger.Info("Subcomando denegado por falta de permisos", zap.String("subcomando", opt.Name), zap.String("guildID", ic.GuildID))
+	if err := handler.responseHandler.RespondWithEphemeralMessage(handler.session, ic.Interaction, message); err != nil {
+		handler.logger.Error("falló al responder con el mensaje de permiso denegado", zap.Error(err))
+	}
+	return false
+}
+
+// removesOwnSongsOnly indica si el miembro solo puede eliminar de la lista de reproducción las canciones que agregó.
+func removesOwnSongsOnly(member *discordgo.Member, permissions config.PermissionsConfig) bool {
+	return permissions.RemoveOwnSongsOnly && !isDJ(member, permissions)
+}
+
+// skipsWithoutVote indica si el miembro salta la canción sin votar: los administradores del servidor y, si el
+// servidor configuró un rol de DJ, quienes lo tienen. Sin rol de DJ todos serían DJs y nadie votaría.
+func skipsWithoutVote(member *discordgo.Member, permissions config.PermissionsConfig) bool {
+	if member == nil {
+		return false
+	}
+	return isGuildManager(member) || (permissions.DJRoleID != "" && hasAnyRole(member, permissions.DJRoleID))
+}
+
+// canUseCommand indica si el miembro puede usar el subcomando. Los administradores del servidor pueden usar todos.
+func canUseCommand(member *discordgo.Member, permissions config.PermissionsConfig, command string) bool {
+	if member == nil || member.User == nil {
+		return false
+	}
+	if isGuildManager(member) {
+		return true
+	}
+	if roles := permissions.CommandRoles[command]; len(roles) > 0 {
+		return hasAnyRole(member, roles...) || (permissions.DJRoleID != "" && hasAnyRole(member, permissions.DJRoleID))
+	}
+	for _, djCommand := range permissions.DJCommands {
+		if djCommand == command {
+			return isDJ(member, permissions)
+		}
+	}
+	return true
+}
+
+// isDJ indica si el miembro tiene el rol de DJ. Si el servidor no configuró un rol de DJ, todos lo son.
+func isDJ(member *discordgo.Member, permissions config.PermissionsConfig) bool {
+	return permissions.DJRoleID == "" || isGuildManager(member) || hasAnyRole(member, permissions.DJRoleID)
+}
+
+// isGuildManager indica si el miembro administra el servidor.
+func isGuildManager(member *discordgo.Member) bool {
+	return member.Permissions&(discordgo.PermissionAdministrator|discordgo.PermissionManageServer) != 0
+}
+
+// hasAnyRole indica si el miembro tiene alguno de los roles indicados.
+func hasAnyRole(member *discordgo.Member, roles ...string) bool {
+	for _, memberRole := range member.Roles {
+		for _, role := range roles {
+			if memberRole == role {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package discord
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/config"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCanUseCommand(t *testing.T) {
+	permissions := config.PermissionsConfig{
+		DJRoleID:     "dj",
+		DJCommands:   []string{"stop", "volume"},
+		CommandRoles: map[string][]string{"seek": {"moderador"}},
+	}
+	member := &discordgo.Member{User: &discordgo.User{ID: "usuario"}}
+	dj := &discordgo.Member{User: &discordgo.User{ID: "dj"}, Roles: []string{"dj"}}
+	moderator := &discordgo.Member{User: &discordgo.User{ID: "moderador"}, Roles: []string{"moderador"}}
+	admin := &discordgo.Member{User: &discordgo.User{ID: "admin"}, Permissions: discordgo.PermissionAdministrator}
+
+	tests := []struct {
+		name    string
+		member  *discordgo.Member
+		command string
+		allowed bool
+	}{
+		{"miembro usa subcomando libre", member, "play", true},
+		{"miembro usa subcomando de DJ", member, "stop", false},
+		{"DJ usa subcomando de DJ", dj, "stop", true},
+		{"administrador usa subcomando de DJ", admin, "volume", true},
+		{"miembro sin rol permitido", member, "seek", false},
+		{"miembro con rol permitido", moderator, "seek", true},
+		{"DJ en subcomando con roles permitidos", dj, "seek", true},
+		{"miembro nulo", nil, "play", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, canUseCommand(tt.member, permissions, tt.command))
+		})
+	}
+}
+
+func TestIsDJ_WithoutDJRole(t *testing.T) {
+	member := &discordgo.Member{User: &discordgo.User{ID: "usuario"}}
+
+	assert.True(t, isDJ(member, config.PermissionsConfig{}))
+	assert.True(t, canUseCommand(member, config.PermissionsConfig{DJCommands: config.DefaultDJCommands}, "stop"))
+}
+
+func TestRemovesOwnSongsOnly(t *testing.T) {
+	permissions := config.PermissionsConfig{DJRoleID: "dj", RemoveOwnSongsOnly: true}
+	member := &discordgo.Member{User: &discordgo.User{ID: "usuario"}}
+	dj := &discordgo.Member{User: &discordgo.User{ID: "dj"}, Roles: []string{"dj"}}
+
+	assert.True(t, removesOwnSongsOnly(member, permissions))
+	assert.False(t, removesOwnSongsOnly(dj, permissions))
+	assert.False(t, removesOwnSongsOnly(member, config.PermissionsConfig{DJRoleID: "dj"}))
+}
+
+func TestSkipsWithoutVote(t *testing.T) {
+	permissions := config.PermissionsConfig{DJRoleID: "dj"}
+	member := &discordgo.Member{User: &discordgo.User{ID: "usuario"}}
+	dj := &discordgo.Member{User: &discordgo.User{ID: "dj"}, Roles: []string{"dj"}}
+	manager := &discordgo.Member{User: &discordgo.User{ID: "admin"}, Permissions: discordgo.PermissionManageServer}
+
+	assert.False(t, skipsWithoutVote(member, permissions))
+	assert.True(t, skipsWithoutVote(dj, permissions))
+	assert.True(t, skipsWithoutVote(manager, permissions))
+	// Sin rol de DJ solo los administradores saltan sin votar.
+	assert.False(t, skipsWithoutVote(member, config.PermissionsConfig{}))
+	assert.True(t, skipsWithoutVote(manager, config.PermissionsConfig{}))
+	assert.False(t, skipsWithoutVote(nil, permissions))
+}
@@ -0,0 +1,86 @@
+package discord
+
+import (
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// PlayerControl maneja los botones del mensaje de reproducción. Cada botón equivale a un subcomando,
+// así que se aplican los mismos permisos y se responde igual que al usar el subcomando.
+func (handler *InteractionHandler) PlayerControl(s *discordgo.Session, ic *discordgo.InteractionCreate) {
+	opt, err := handler.playerControlOption(s, ic)
+	if err != nil {
+		handler.logger.Error("falló al obtener el subcomando del botón", zap.Error(err))
+		if err := handler.responseHandler.RespondWithEphemeralMessage(handler.session, ic.Interaction, "Ocurrió un error al obtener el estado del reproductor"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+	if !handler.Authorize(s, ic, opt) {
+		return
+	}
+
+	switch opt.Name {
+	case "pause":
+		handler.PauseSong(s, ic, opt)
+	case "resume":
+		handler.ResumeSong(s, ic, opt)
+	case "skip":
+		handler.SkipSong(s, ic, opt)
+	case "stop":
+		handler.StopPlaying(s, ic, opt)
+	case "loop":
+		handler.SetLoopMode(s, ic, opt)
+	case "shuffle":
+		handler.ShufflePlaylist(s, ic, opt)
+	case "list":
+		handler.ListPlaylist(s, ic, opt)
+	}
+}
+
+// playerControlOption traduce el botón presionado al subcomando equivalente. Los botones de pausa y de repetición
+// dependen del estado del reproductor: el primero alterna entre pausar y reanudar, y el segundo pasa al modo siguiente.
+func (handler *InteractionHandler) playerControlOption(s *discordgo.Session, ic *discordgo.InteractionCreate) (*discordgo.ApplicationCommandInteractionDataOption, error) {
+	customID := ic.MessageComponentData().CustomID
+	switch customID {
+	case voice.PlayerControlPauseResume:
+		player, err := handler.getGuildPlayer(GuildID(ic.GuildID), s)
+		if err != nil {
+			return nil, err
+		}
+		paused, err := player.IsPaused()
+		if err != nil {
+			return nil, err
+		}
+		if paused {
+			return &discordgo.ApplicationCommandInteractionDataOption{Name: "resume"}, nil
+		}
+		return &discordgo.ApplicationCommandInteractionDataOption{Name: "pause"}, nil
+	case voice.PlayerControlSkip:
+		return &discordgo.ApplicationCommandInteractionDataOption{Name: "skip"}, nil
+	case voice.PlayerControlStop:
+		return &discordgo.ApplicationCommandInteractionDataOption{Name: "stop"}, nil
+	case voice.PlayerControlLoop:
+		player, err := handler.getGuildPlayer(GuildID(ic.GuildID), s)
+		if err != nil {
+			return nil, err
+		}
+		mode, err := player.GetLoopMode()
+		if err != nil {
+			return nil, err
+		}
+		return &discordgo.ApplicationCommandInteractionDataOption{
+			Name: "loop",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: "mode", Type: discordgo.ApplicationCommandOptionString, Value: string(mode.Next())},
+			},
+		}, nil
+	case voice.PlayerControlShuffle:
+		return &discordgo.ApplicationCommandInteractionDataOption{Name: "shuffle"}, nil
+	case voice.PlayerControlQueue:
+		return &discordgo.ApplicationCommandInteractionDataOption{Name: "list"}, nil
+	}
+	return nil, fmt.Errorf("botón desconocido: %s", customID)
+}
@@ -0,0 +1,331 @@
+package discord
+
+import (
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/utils"
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// IDs de los botones para cambiar de página en la vista de la lista de reproducción.
+const (
+	QueuePagePrevious = "queue_page_previous"
+	QueuePageNext     = "queue_page_next"
+)
+
+const (
+	// defaultQueuePageSize es la cantidad de canciones por página si no se configuró otra.
+	defaultQueuePageSize = 10
+	// maxQueuePageSize es la cantidad máxima de canciones por página. El largo de la descripción lo limita
+	// GenerateQueueEmbed, porque con títulos o URLs largas menos canciones ya superan el límite de Discord.
+	maxQueuePageSize = 20
+	// maxEmbedDescriptionLength es la cantidad máxima de caracteres de la descripción de un embed de Discord.
+	maxEmbedDescriptionLength = 4096
+	// queueCutNoteLength es el lugar que se reserva en la descripción para avisar que la página no entró completa.
+	queueCutNoteLength = 64
+	// maxQueueTitleLength es la cantidad máxima de caracteres del título de una canción en la vista.
+	maxQueueTitleLength = 80
+	// maxQueueRequesterLength es la cantidad máxima de caracteres del nombre de quien pidió una canción en la vista.
+	maxQueueRequesterLength = 32
+	// maxQueueLinkLength es el largo máximo de las URLs que se enlazan en la vista. Las más largas, como las de los
+	// archivos adjuntos firmados, se omiten y solo se muestra el título.
+	maxQueueLinkLength = 200
+)
+
+// queueViewStore guarda la página que muestra cada vista de la lista de reproducción, indexada por el ID
+// de la interacción que la creó. Las vistas expiran después de ttl sin usarse.
+type queueViewStore struct {
+	mu    sync.Mutex
+	pages map[string]queueViewPage
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+// queueViewPage es la página que muestra una vista y hasta cuándo se pueden usar sus botones.
+type queueViewPage struct {
+	page    int
+	expires time.Time
+}
+
+// newQueueViewStore crea un almacenamiento de vistas cuyas páginas expiran después de ttl.
+func newQueueViewStore(ttl time.Duration) *queueViewStore {
+	return &queueViewStore{
+		pages: make(map[string]queueViewPage),
+		ttl:   ttl,
+		now:   time.Now,
+	}
+}
+
+// save guarda la página de la vista y renueva su vencimiento. También descarta las vistas vencidas.
+func (s *queueViewStore) save(viewID string, page int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for id, view := range s.pages {
+		if now.After(view.expires) {
+			delete(s.pages, id)
+		}
+	}
+	s.pages[viewID] = queueViewPage{page: page, expires: now.Add(s.ttl)}
+}
+
+// get devuelve la página de la vista, o false si no existe o ya venció.
+func (s *queueViewStore) get(viewID string) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	view, ok := s.pages[viewID]
+	if !ok || s.now().After(view.expires) {
+		return 0, false
+	}
+	return view.page, true
+}
+
+// ListPlaylist muestra la primera página de la lista de reproducción, con botones para navegar entre páginas.
+func (handler *InteractionHandler) ListPlaylist(s *discordgo.Session, ic *discordgo.InteractionCreate, acido *discordgo.ApplicationCommandInteractionDataOption) {
+	g, err := s.State.Guild(ic.GuildID)
+	if err != nil {
+		handler.logger.Info("falló al obtener el servidor", zap.Error(err))
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al obtener la información del servidor"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
+	handler.commandUsageCounter.Inc("ListPlaylist")
+	playlist, err := player.GetPlaylist()
+	if err != nil {
+		handler.logger.Error("falló al obtener la lista de reproducción", zap.Error(err))
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al obtener la lista de reproducción"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	if len(playlist) == 0 {
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "🫙 La lista de reproducción está vacía"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	currentSong, err := player.GetPlayedSong()
+	if err != nil {
+		handler.logger.Error("falló al obtener la canción actual", zap.Error(err))
+	}
+
+	pageSize := handler.queuePageSize()
+	data := &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{GenerateQueueEmbed(playlist, currentSong, 0, pageSize, time.Now())},
+	}
+	if pages := queuePageCount(len(playlist), pageSize); pages > 1 {
+		data.Components = GenerateQueuePageButtons(0, pages)
+		handler.queueViews.save(ic.ID, 0)
+	}
+
+	if err := handler.responseHandler.Respond(handler.session, ic.Interaction, discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	}); err != nil {
+		handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+	}
+}
+
+// QueuePage maneja los botones de página de la vista de la lista de reproducción y actualiza el mensaje
+// con la página pedida de la lista de reproducción actual.
+func (handler *InteractionHandler) QueuePage(s *discordgo.Session, ic *discordgo.InteractionCreate) {
+	var viewID string
+	if ic.Message != nil && ic.Message.Interaction != nil {
+		viewID = ic.Message.Interaction.ID
+	}
+	page, ok := handler.queueViews.get(viewID)
+	if !ok {
+		message := fmt.Sprintf("⌛ Esta vista de la lista de reproducción expiró, usá `/%s list` para verla de nuevo", handler.cfg.CommandPrefix)
+		if err := handler.responseHandler.RespondWithEphemeralMessage(handler.session, ic.Interaction, message); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	player, ok := handler.getInteractionPlayer(GuildID(ic.GuildID), s, ic)
+	if !ok {
+		return
+	}
+	playlist, err := player.GetPlaylist()
+	if err != nil {
+		handler.logger.Error("falló al obtener la lista de reproducción", zap.Error(err))
+		if err := handler.responseHandler.RespondWithEphemeralMessage(handler.session, ic.Interaction, "Ocurrió un error al obtener la lista de reproducción"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+	currentSong, err := player.GetPlayedSong()
+	if err != nil {
+		handler.logger.Error("falló al obtener la canción actual", zap.Error(err))
+	}
+
+	if ic.MessageComponentData().CustomID == QueuePagePrevious {
+		page--
+	} else {
+		page++
+	}
+	// La lista de reproducción pudo cambiar desde que se mostró la vista.
+	pageSize := handler.queuePageSize()
+	pages := queuePageCount(len(playlist), pageSize)
+	page = min(max(page, 0), pages-1)
+	handler.queueViews.save(viewID, page)
+
+	if err := handler.responseHandler.Respond(handler.session, ic.Interaction, discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{GenerateQueueEmbed(playlist, currentSong, page, pageSize, time.Now())},
+			Components: GenerateQueuePageButtons(page, pages),
+		},
+	}); err != nil {
+		handler.logger.Error("falló al actualizar la vista de la lista de reproducción", zap.Error(err))
+	}
+}
+
+// queuePageSize devuelve la cantidad de canciones por página configurada, dentro de los límites permitidos.
+func (handler *InteractionHandler) queuePageSize() int {
+	if handler.cfg.QueuePageSize <= 0 {
+		return defaultQueuePageSize
+	}
+	return min(handler.cfg.QueuePageSize, maxQueuePageSize)
+}
+
+// queuePageCount devuelve la cantidad de páginas necesarias para mostrar las canciones. Siempre hay al menos una.
+func queuePageCount(songs, pageSize int) int {
+	return max(1, (songs+pageSize-1)/pageSize)
+}
+
+// GenerateQueueEmbed genera una página de la lista de reproducción con la posición, la duración, quién pidió cada
+// canción y a qué hora se estima que empieza. La primera página es la 0. La hora estimada se calcula desde now con
+// lo que falta de la canción actual, y deja de mostrarse a partir de la primera canción de duración desconocida.
+func GenerateQueueEmbed(songs []*voice.Song, currentSong *voice.PlayedSong, page, pageSize int, now time.Time) *discordgo.MessageEmbed {
+	pages := queuePageCount(len(songs), pageSize)
+
+	var total time.Duration
+	for _, song := range songs {
+		total += song.Duration
+	}
+
+	offset, known := time.Duration(0), true
+	if currentSong != nil {
+		offset = currentSong.Duration - currentSong.Position
+		known = currentSong.Duration > 0
+	}
+
+	var header string
+	if currentSong != nil {
+		header = fmt.Sprintf("▶️ Sonando: **%s** (%s / %s)\n\n", truncateText(currentSong.GetHumanName(), maxQueueTitleLength), utils.FmtDuration(currentSong.Position), utils.FmtDuration(currentSong.Duration))
+	}
+
+	// Primero se arman las líneas sin enlace, que siempre entran con los largos máximos de la vista. Después se
+	// agregan los enlaces en orden mientras la descripción no supere el límite de Discord.
+	var plain, linked []string
+	start, end := page*pageSize, min((page+1)*pageSize, len(songs))
+	for i, song := range songs {
+		if i >= end {
+			break
+		}
+		if i >= start {
+			plain = append(plain, formatQueueLine(i+1, song, now.Add(offset), known, false))
+			linked = append(linked, formatQueueLine(i+1, song, now.Add(offset), known, true))
+		}
+		offset += song.Duration
+		known = known && song.Duration > 0
+	}
+
+	limit := maxEmbedDescriptionLength - utf8.RuneCountInString(header) - queueCutNoteLength
+	length := 0
+	for i, line := range plain {
+		length += utf8.RuneCountInString(line)
+		if length > limit {
+			// Por si cambian los largos máximos: la página termina antes en vez de superar el límite.
+			plain = append(plain[:i], fmt.Sprintf("`…` %d canciones más no entran en esta página\n", len(linked)-i))
+			linked = nil
+			break
+		}
+	}
+	for i := range linked {
+		extra := utf8.RuneCountInString(linked[i]) - utf8.RuneCountInString(plain[i])
+		if length+extra > limit {
+			break
+		}
+		plain[i] = linked[i]
+		length += extra
+	}
+
+	description := header + strings.TrimSpace(strings.Join(plain, ""))
+
+	return &discordgo.MessageEmbed{
+		Title:       "Lista de reproducción:",
+		Description: description,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Página %d/%d · %d canciones · %s en total", page+1, pages, len(songs), utils.FmtDuration(total)),
+		},
+	}
+}
+
+// formatQueueLine arma la línea de una canción en la vista de la lista de reproducción. Con link, el título enlaza
+// a la URL de la canción si no es demasiado larga.
+func formatQueueLine(position int, song *voice.Song, startsAt time.Time, known, link bool) string {
+	title := truncateText(song.GetHumanName(), maxQueueTitleLength)
+	if link && song.URL != "" && len(song.URL) <= maxQueueLinkLength {
+		title = fmt.Sprintf("[%s](%s)", title, song.URL)
+	}
+
+	requester := "📻 reproducción automática"
+	if song.RequestedBy != nil {
+		requester = truncateText(*song.RequestedBy, maxQueueRequesterLength)
+		if song.UploadedBy != "" {
+			// Los dos nombres comparten el lugar del solicitante para que la página siga entrando en la descripción.
+			requester = fmt.Sprintf("%s · subido por %s", truncateText(*song.RequestedBy, maxQueueRequesterLength/2),
+				truncateText(song.UploadedBy, maxQueueRequesterLength/2))
+		}
+	} else if !song.Autoplay {
+		requester = "desconocido"
+	}
+
+	eta := "hora desconocida"
+	if known {
+		// Discord muestra la marca de tiempo en la zona horaria de cada usuario.
+		eta = fmt.Sprintf("<t:%d:t>", startsAt.Unix())
+	}
+	return fmt.Sprintf("`%d.` %s · `%s` · %s · empieza %s\n", position, title, utils.FmtDuration(song.Duration), requester, eta)
+}
+
+// GenerateQueuePageButtons genera los botones para ir a la página anterior y a la siguiente de la lista de reproducción.
+func GenerateQueuePageButtons(page, pages int) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					CustomID: QueuePagePrevious,
+					Label:    "Anterior",
+					Style:    discordgo.SecondaryButton,
+					Emoji:    &discordgo.ComponentEmoji{Name: "⬅️"},
+					Disabled: page <= 0,
+				},
+				discordgo.Button{
+					CustomID: QueuePageNext,
+					Label:    "Siguiente",
+					Style:    discordgo.SecondaryButton,
+					Emoji:    &discordgo.ComponentEmoji{Name: "➡️"},
+					Disabled: page >= pages-1,
+				},
+			},
+		},
+	}
+}
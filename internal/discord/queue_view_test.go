@@ -0,0 +1,112 @@
+package discord
+
+import (
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/utils"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestQueueViewStore_Expires(t *testing.T) {
+	now := time.Now()
+	store := newQueueViewStore(time.Minute)
+	store.now = func() time.Time { return now }
+
+	store.save("vista", 2)
+	page, ok := store.get("vista")
+	assert.True(t, ok)
+	assert.Equal(t, 2, page)
+
+	now = now.Add(2 * time.Minute)
+	_, ok = store.get("vista")
+	assert.False(t, ok)
+
+	store.save("otra", 0)
+	assert.NotContains(t, store.pages, "vista")
+}
+
+func TestGenerateQueueEmbed_Page(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	songs := make([]*voice.Song, 5)
+	for i := range songs {
+		songs[i] = &voice.Song{Title: fmt.Sprintf("canción %d", i+1), Duration: time.Minute, RequestedBy: utils.String("ana")}
+	}
+	current := &voice.PlayedSong{Song: voice.Song{Title: "actual", Duration: 3 * time.Minute}, Position: time.Minute}
+
+	embed := GenerateQueueEmbed(songs, current, 1, 2, now)
+
+	assert.Contains(t, embed.Description, "▶️ Sonando: **actual**")
+	assert.NotContains(t, embed.Description, "canción 2")
+	assert.Contains(t, embed.Description, "`3.` canción 3 · `01:00` · ana")
+	// La tercera canción empieza cuando terminan la actual (2 minutos) y las dos anteriores.
+	assert.Contains(t, embed.Description, fmt.Sprintf("<t:%d:t>", now.Add(4*time.Minute).Unix()))
+	assert.NotContains(t, embed.Description, "canción 5")
+	assert.Equal(t, "Página 2/3 · 5 canciones · 05:00 en total", embed.Footer.Text)
+}
+
+func TestGenerateQueueEmbed_UnknownDuration(t *testing.T) {
+	songs := []*voice.Song{
+		{Title: "radio", Autoplay: true},
+		{Title: "después"},
+	}
+
+	embed := GenerateQueueEmbed(songs, nil, 0, 10, time.Now())
+
+	assert.Contains(t, embed.Description, "`1.` radio · `00:00` · 📻 reproducción automática · empieza <t:")
+	assert.Contains(t, embed.Description, "`2.` después · `00:00` · desconocido · empieza hora desconocida")
+}
+
+func TestGenerateQueueEmbed_LongEntriesFitDescription(t *testing.T) {
+	longTitle := strings.Repeat("á", 300)
+	requester := strings.Repeat("r", 100)
+	songs := make([]*voice.Song, maxQueuePageSize)
+	for i := range songs {
+		url := fmt.Sprintf("https://cdn.discordapp.com/attachments/%d/%s", i, strings.Repeat("x", maxQueueLinkLength-50))
+		songs[i] = &voice.Song{Title: longTitle, URL: url, Duration: time.Minute, RequestedBy: &requester}
+	}
+	current := &voice.PlayedSong{Song: voice.Song{Title: longTitle, Duration: time.Minute}}
+
+	embed := GenerateQueueEmbed(songs, current, 0, maxQueuePageSize, time.Now())
+
+	assert.LessOrEqual(t, utf8.RuneCountInString(embed.Description), maxEmbedDescriptionLength)
+	assert.NotContains(t, embed.Description, longTitle)
+	assert.NotContains(t, embed.Description, requester)
+	// Las canciones que no entran con enlace se muestran sin él, así que la página sigue completa.
+	assert.Contains(t, embed.Description, fmt.Sprintf("`%d.` ", maxQueuePageSize))
+	assert.Contains(t, embed.Description, "](https://cdn.discordapp.com/attachments/0/")
+	assert.NotContains(t, embed.Description, "no entran en esta página")
+}
+
+func TestGenerateQueueEmbed_UploadedBy(t *testing.T) {
+	requestedBy := "Fito"
+	songs := []*voice.Song{{Title: "demo.mp3", RequestedBy: &requestedBy, UploadedBy: "Charly"}}
+
+	embed := GenerateQueueEmbed(songs, nil, 0, 10, time.Now())
+
+	assert.Contains(t, embed.Description, "· Fito · subido por Charly ·")
+}
+
+func TestGenerateQueueEmbed_OmitsLongLinks(t *testing.T) {
+	url := "https://cdn.discordapp.com/attachments/1/" + strings.Repeat("x", maxQueueLinkLength)
+	songs := []*voice.Song{{Title: "adjunto.mp3", URL: url}}
+
+	embed := GenerateQueueEmbed(songs, nil, 0, 10, time.Now())
+
+	assert.Contains(t, embed.Description, "`1.` adjunto.mp3 · ")
+	assert.NotContains(t, embed.Description, url)
+}
+
+func TestGenerateQueuePageButtons(t *testing.T) {
+	first := GenerateQueuePageButtons(0, 3)[0].(discordgo.ActionsRow).Components
+	last := GenerateQueuePageButtons(2, 3)[0].(discordgo.ActionsRow).Components
+
+	assert.True(t, first[0].(discordgo.Button).Disabled)
+	assert.False(t, first[1].(discordgo.Button).Disabled)
+	assert.False(t, last[0].(discordgo.Button).Disabled)
+	assert.True(t, last[1].(discordgo.Button).Disabled)
+}
@@ -35,6 +35,7 @@ func (s *DiscordSessionService) FollowupMessageCreate(i *discordgo.Interaction,
 type ResponseHandler interface {
 	Respond(session SessionService, interaction *discordgo.Interaction, response discordgo.InteractionResponse) error
 	RespondWithMessage(session SessionService, interaction *discordgo.Interaction, message string) error
+	RespondWithEphemeralMessage(session SessionService, interaction *discordgo.Interaction, message string) error
 	CreateFollowupMessage(session SessionService, interaction *discordgo.Interaction, params discordgo.WebhookParams) error
 }
 
@@ -68,6 +69,18 @@ func (h *DiscordResponseHandler) RespondWithMessage(session SessionService, inte
 	return h.Respond(session, interaction, response)
 }
 
+// RespondWithEphemeralMessage responde a una interacción de Discord con un mensaje de texto visible solo para quien la envió.
+func (h *DiscordResponseHandler) RespondWithEphemeralMessage(session SessionService, interaction *discordgo.Interaction, message string) error {
+	response := discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: message,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	}
+	return h.Respond(session, interaction, response)
+}
+
 // CreateFollowupMessage crea un mensaje de seguimiento para una interacción de Discord.
 func (h *DiscordResponseHandler) CreateFollowupMessage(session SessionService, interaction *discordgo.Interaction, params discordgo.WebhookParams) error {
 	if _, err := session.FollowupMessageCreate(interaction, true, &params); err != nil {
@@ -96,6 +96,31 @@ func TestDiscordResponseHandler_RespondWithMessage(t *testing.T) {
 	mockSession.AssertExpectations(t)
 }
 
+func TestDiscordResponseHandler_RespondWithEphemeralMessage(t *testing.T) {
+	mockLogger := new(logging.MockLogger)
+	responseHandler := NewDiscordResponseHandler(mockLogger)
+	mockSession := new(MockSessionService)
+
+	interaction := &discordgo.Interaction{}
+	message := "Solo vos podés ver esto"
+	expectedResponse := discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: message,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	}
+
+	mockSession.On("InteractionRespond", interaction, &expectedResponse).Return(nil)
+
+	err := responseHandler.RespondWithEphemeralMessage(mockSession, interaction, message)
+	if err != nil {
+		t.Errorf("Se esperaba error nulo, pero se obtuvo: %v", err)
+	}
+
+	mockSession.AssertExpectations(t)
+}
+
 func TestDiscordResponseHandler_CreateFollowupMessage(t *testing.T) {
 	mockLogger := new(logging.MockLogger)
 	responseHandler := NewDiscordResponseHandler(mockLogger)
@@ -0,0 +1,213 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/bot"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/music/fetcher"
+	"github.com/Tomas-vilte/GoMusicBot/internal/utils"
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+	"strings"
+)
+
+// SearchResultSelect es el ID del menú para elegir uno de los resultados de /search.
+const SearchResultSelect = "search_result"
+
+const (
+	// defaultSearchResults es la cantidad de resultados que muestra /search si no se configuró otra.
+	defaultSearchResults = 5
+	// maxSearchResults es la cantidad máxima de opciones que admite un menú de selección de Discord.
+	maxSearchResults = 25
+	// maxSelectOptionLength es la longitud máxima de la etiqueta y la descripción de una opción del menú.
+	maxSelectOptionLength = 100
+)
+
+// SearchSong maneja el comando de búsqueda: muestra los primeros resultados de YouTube en un menú
+// para que el miembro elija cuál agregar a la lista de reproducción.
+func (handler *InteractionHandler) SearchSong(ctx context.Context, s *discordgo.Session, ic *discordgo.InteractionCreate, opt *discordgo.ApplicationCommandInteractionDataOption) {
+	g, err := s.State.Guild(ic.GuildID)
+	if err != nil {
+		handler.logger.Info("falló al obtener el servidor", zap.Error(err))
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, "Ocurrió un error al obtener la información del servidor"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+	handler.commandUsageCounter.Inc("SearchSong")
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(opt.Options))
+	for _, opt := range opt.Options {
+		optionMap[opt.Name] = opt
+	}
+
+	query := optionMap["query"].StringValue()
+	if vs := getUsersVoiceState(g, ic.Member.User); vs == nil {
+		if err := handler.responseHandler.RespondWithMessage(handler.session, ic.Interaction, ErrorMessageNotInVoiceChannel); err != nil {
+			handler.logger.Error("falló al responder con el error de no estar en un canal de voz", zap.Error(err))
+		}
+		return
+	}
+	if err := handler.responseHandler.Respond(handler.session, ic.Interaction, discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{GenerateSearchingSongEmbed(query, ic.Member)},
+		},
+	}); err != nil {
+		handler.logger.Error("fallo al enviar la respuesta diferida", zap.Error(err))
+	}
+
+	go func(ic *discordgo.InteractionCreate) {
+		songs, err := handler.songLookup.SearchSongs(ctx, query, int64(handler.searchResults()))
+		if err != nil {
+			if !errors.Is(err, fetcher.ErrNoSearchResults) {
+				handler.logger.Error("Error al buscar canciones en YouTube", zap.Error(err), zap.String("query", query))
+			}
+			if err := handler.responseHandler.CreateFollowupMessage(handler.session, ic.Interaction, discordgo.WebhookParams{
+				Embeds: []*discordgo.MessageEmbed{GenerateFailedToFindSong(query, ic.Member)},
+			}); err != nil {
+				handler.logger.Error("falló al enviar el mensaje de seguimiento de búsqueda sin resultados", zap.Error(err))
+			}
+			return
+		}
+
+		if err := handler.responseHandler.CreateFollowupMessage(handler.session, ic.Interaction, discordgo.WebhookParams{
+			Embeds:     []*discordgo.MessageEmbed{GenerateSearchResultsEmbed(query, songs, ic.Member)},
+			Components: GenerateSearchResultsMenu(songs),
+		}); err != nil {
+			handler.logger.Error("falló al enviar el mensaje de seguimiento con los resultados de búsqueda", zap.Error(err))
+		}
+	}(ic)
+}
+
+// AddSearchResult agrega a la lista de reproducción la canción elegida en el menú de resultados de /search.
+// Solo quien hizo la búsqueda puede elegir, y el menú se quita del mensaje después de agregar la canción.
+func (handler *InteractionHandler) AddSearchResult(s *discordgo.Session, ic *discordgo.InteractionCreate) {
+	if ic.Message != nil && ic.Message.Interaction != nil && ic.Message.Interaction.User != nil && ic.Message.Interaction.User.ID != ic.Member.User.ID {
+		if err := handler.responseHandler.RespondWithEphemeralMessage(handler.session, ic.Interaction, ErrorMessageSearchNotOwner); err != nil {
+			handler.logger.Error("falló al responder con el error de búsqueda ajena", zap.Error(err))
+		}
+		return
+	}
+
+	values := ic.MessageComponentData().Values
+	g, err := s.State.Guild(ic.GuildID)
+	if err != nil || len(values) == 0 {
+		handler.logger.Info("falló al obtener el servidor o el resultado elegido", zap.Error(err))
+		if err := handler.responseHandler.RespondWithEphemeralMessage(handler.session, ic.Interaction, "Ocurrió un error al obtener la información del servidor"); err != nil {
+			handler.logger.Error("falló al responder con el error del servidor", zap.Error(err))
+		}
+		return
+	}
+
+	vs := getUsersVoiceState(g, ic.Member.User)
+	if vs == nil {
+		if err := handler.responseHandler.RespondWithEphemeralMessage(handler.session, ic.Interaction, ErrorMessageNotInVoiceChannel); err != nil {
+			handler.logger.Error("falló al responder con el error de no estar en un canal de voz", zap.Error(err))
+		}
+		return
+	}
+
+	songs, err := handler.sources.Resolve(context.Background(), values[0])
+	if err != nil || len(songs) == 0 {
+		handler.logger.Info("falló al buscar la metadata del resultado elegido", zap.Error(err), zap.String("url", values[0]))
+		if err := handler.responseHandler.RespondWithEphemeralMessage(handler.session, ic.Interaction, ErrorMessageFailedToAddSong); err != nil {
+			handler.logger.Error("falló al responder con el error al agregar la canción", zap.Error(err))
+		}
+		return
+	}
+
+	// Se etiqueta una copia para no modificar la canción compartida con la caché de búsquedas.
+	song := *songs[0]
+	memberName := getMemberName(ic.Member)
+	song.RequestedBy = &memberName
+	song.RequesterID = ic.Member.User.ID
+
+	player, ok := handler.getInteractionPlayer(GuildID(g.ID), s, ic)
+	if !ok {
+		return
+	}
+	result, err := player.AddSong(&ic.ChannelID, &vs.ChannelID, &song)
+	if err != nil {
+		message := ErrorMessageRequesterLimit
+		if !errors.Is(err, bot.ErrRequesterLimit) {
+			handler.logger.Info("falló al agregar la canción", zap.Error(err), zap.String("url", song.URL))
+			message = ErrorMessageFailedToAddSong
+		}
+		if err := handler.responseHandler.RespondWithEphemeralMessage(handler.session, ic.Interaction, message); err != nil {
+			handler.logger.Error("falló al responder con el error al agregar la canción", zap.Error(err))
+		}
+		return
+	}
+
+	if err := handler.responseHandler.Respond(handler.session, ic.Interaction, discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{GenerateAddedSongEmbed(&song, ic.Member, result)},
+			Components: []discordgo.MessageComponent{},
+		},
+	}); err != nil {
+		handler.logger.Error("falló al responder con la canción agregada", zap.Error(err))
+	}
+}
+
+// searchResults devuelve la cantidad de resultados configurada para /search, dentro de los límites de Discord.
+func (handler *InteractionHandler) searchResults() int {
+	if handler.cfg.SearchResults <= 0 {
+		return defaultSearchResults
+	}
+	return min(handler.cfg.SearchResults, maxSearchResults)
+}
+
+// GenerateSearchingSongEmbed genera el mensaje que se muestra mientras se busca la canción.
+func GenerateSearchingSongEmbed(query string, member *discordgo.Member) *discordgo.MessageEmbed {
+	return generateAddingSongEmbed(query, "🔎  Buscando canciones...", member)
+}
+
+// GenerateSearchResultsEmbed genera el mensaje con los resultados de la búsqueda: título, canal y duración de cada canción.
+func GenerateSearchResultsEmbed(query string, songs []*voice.Song, member *discordgo.Member) *discordgo.MessageEmbed {
+	builder := strings.Builder{}
+	for i, song := range songs {
+		builder.WriteString(fmt.Sprintf("`%d.` **%s** · %s · `%s`\n", i+1, song.GetHumanName(), song.Channel, utils.FmtDuration(song.Duration)))
+	}
+	builder.WriteString("\nElegí en el menú la canción que querés agregar.")
+	return generateAddingSongEmbed(fmt.Sprintf("🔎  Resultados para \"%s\"", query), builder.String(), member)
+}
+
+// GenerateSearchResultsMenu genera el menú para elegir uno de los resultados de la búsqueda.
+func GenerateSearchResultsMenu(songs []*voice.Song) []discordgo.MessageComponent {
+	options := make([]discordgo.SelectMenuOption, len(songs))
+	for i, song := range songs {
+		options[i] = discordgo.SelectMenuOption{
+			Label:       truncateSelectOption(fmt.Sprintf("%d. %s", i+1, song.GetHumanName())),
+			Description: truncateSelectOption(fmt.Sprintf("%s · %s", song.Channel, utils.FmtDuration(song.Duration))),
+			Value:       song.URL,
+		}
+	}
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    SearchResultSelect,
+					Placeholder: "Elegí una canción",
+					Options:     options,
+				},
+			},
+		},
+	}
+}
+
+// truncateSelectOption recorta el texto a la longitud máxima de una opción del menú sin cortar caracteres.
+func truncateSelectOption(text string) string {
+	return truncateText(text, maxSelectOptionLength)
+}
+
+// truncateText recorta el texto a maxLength caracteres sin cortar caracteres, terminándolo en "…" si se recortó.
+func truncateText(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	return string(runes[:maxLength-1]) + "…"
+}
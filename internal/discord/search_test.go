@@ -0,0 +1,28 @@
+package discord
+
+import (
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateSearchResultsMenu(t *testing.T) {
+	songs := []*voice.Song{
+		{Title: "Tema original", Channel: "Banda", Duration: 3 * time.Minute, URL: "https://www.youtube.com/watch?v=original"},
+		{Title: strings.Repeat("á", 150), Channel: "Otro canal", URL: "https://www.youtube.com/watch?v=largo"},
+	}
+
+	components := GenerateSearchResultsMenu(songs)
+
+	menu := components[0].(discordgo.ActionsRow).Components[0].(discordgo.SelectMenu)
+	assert.Equal(t, SearchResultSelect, menu.CustomID)
+	assert.Len(t, menu.Options, 2)
+	assert.Equal(t, "1. Tema original", menu.Options[0].Label)
+	assert.Equal(t, "Banda · 03:00", menu.Options[0].Description)
+	assert.Equal(t, "https://www.youtube.com/watch?v=original", menu.Options[0].Value)
+	assert.Len(t, []rune(menu.Options[1].Label), maxSelectOptionLength)
+	assert.True(t, strings.HasSuffix(menu.Options[1].Label, "…"))
+}
@@ -2,6 +2,7 @@ package discord
 
 import (
 	"context"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -9,12 +10,30 @@ import (
 type SlashCommandRouter struct {
 	commandPrefix            string
 	playHandler              func(context.Context, *discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
+	playNextHandler          func(context.Context, *discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
+	searchHandler            func(context.Context, *discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
 	stopHandler              func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
 	listHandler              func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
 	skipHandler              func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
 	removeHandler            func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
 	playingNowHandler        func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
+	pauseHandler             func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
+	resumeHandler            func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
+	seekHandler              func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
+	loopHandler              func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
+	volumeHandler            func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
+	autoplayHandler          func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
+	historyHandler           func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
+	previousHandler          func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
+	shuffleHandler           func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
+	moveHandler              func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
 	addSongOrPlaylistHandler func(*discordgo.Session, *discordgo.InteractionCreate)
+	playerControlHandler     func(*discordgo.Session, *discordgo.InteractionCreate)
+	queuePageHandler         func(*discordgo.Session, *discordgo.InteractionCreate)
+	searchResultHandler      func(*discordgo.Session, *discordgo.InteractionCreate)
+	playAutocompleteHandler  func(context.Context, *discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)
+	playAttachmentHandler    func(context.Context, *discordgo.Session, *discordgo.InteractionCreate)
+	authorizeHandler         func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption) bool
 }
 
 // NewSlashCommandRouter crea una nueva instancia de SlashCommandRouter con el prefijo de comando especificado.
@@ -30,6 +49,18 @@ func (ch *SlashCommandRouter) PlayHandler(h func(context.Context, *discordgo.Ses
 	return ch
 }
 
+// PlayNextHandler establece el manejador para el comando "playnext".
+func (ch *SlashCommandRouter) PlayNextHandler(h func(context.Context, *discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)) *SlashCommandRouter {
+	ch.playNextHandler = h
+	return ch
+}
+
+// SearchHandler establece el manejador para el comando "search".
+func (ch *SlashCommandRouter) SearchHandler(h func(context.Context, *discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)) *SlashCommandRouter {
+	ch.searchHandler = h
+	return ch
+}
+
 // StopHandler establece el manejador para el comando "stop".
 func (ch *SlashCommandRouter) StopHandler(h func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)) *SlashCommandRouter {
 	ch.stopHandler = h
@@ -60,22 +91,126 @@ func (ch *SlashCommandRouter) PlayingNowHandler(h func(*discordgo.Session, *disc
 	return ch
 }
 
+// PauseHandler establece el manejador para el comando "pause".
+func (ch *SlashCommandRouter) PauseHandler(h func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)) *SlashCommandRouter {
+	ch.pauseHandler = h
+	return ch
+}
+
+// ResumeHandler establece el manejador para el comando "resume".
+func (ch *SlashCommandRouter) ResumeHandler(h func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)) *SlashCommandRouter {
+	ch.resumeHandler = h
+	return ch
+}
+
+// SeekHandler establece el manejador para el comando "seek".
+func (ch *SlashCommandRouter) SeekHandler(h func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)) *SlashCommandRouter {
+	ch.seekHandler = h
+	return ch
+}
+
+// LoopHandler establece el manejador para el comando "loop".
+func (ch *SlashCommandRouter) LoopHandler(h func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)) *SlashCommandRouter {
+	ch.loopHandler = h
+	return ch
+}
+
+// VolumeHandler establece el manejador para el comando "volume".
+func (ch *SlashCommandRouter) VolumeHandler(h func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)) *SlashCommandRouter {
+	ch.volumeHandler = h
+	return ch
+}
+
+// AutoplayHandler establece el manejador para el comando "autoplay".
+func (ch *SlashCommandRouter) AutoplayHandler(h func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)) *SlashCommandRouter {
+	ch.autoplayHandler = h
+	return ch
+}
+
+// HistoryHandler establece el manejador para el comando "history".
+func (ch *SlashCommandRouter) HistoryHandler(h func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)) *SlashCommandRouter {
+	ch.historyHandler = h
+	return ch
+}
+
+// PreviousHandler establece el manejador para el comando "previous".
+func (ch *SlashCommandRouter) PreviousHandler(h func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)) *SlashCommandRouter {
+	ch.previousHandler = h
+	return ch
+}
+
+// ShuffleHandler establece el manejador para el comando "shuffle".
+func (ch *SlashCommandRouter) ShuffleHandler(h func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)) *SlashCommandRouter {
+	ch.shuffleHandler = h
+	return ch
+}
+
+// MoveHandler establece el manejador para el comando "move".
+func (ch *SlashCommandRouter) MoveHandler(h func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)) *SlashCommandRouter {
+	ch.moveHandler = h
+	return ch
+}
+
 // AddSongOrPlaylistHandler establece el manejador para el comando "add_song_playlist".
 func (ch *SlashCommandRouter) AddSongOrPlaylistHandler(h func(*discordgo.Session, *discordgo.InteractionCreate)) *SlashCommandRouter {
 	ch.addSongOrPlaylistHandler = h
 	return ch
 }
 
+// PlayerControlHandler establece el manejador para los botones del mensaje de reproducción.
+func (ch *SlashCommandRouter) PlayerControlHandler(h func(*discordgo.Session, *discordgo.InteractionCreate)) *SlashCommandRouter {
+	ch.playerControlHandler = h
+	return ch
+}
+
+// QueuePageHandler establece el manejador para los botones de página de la lista de reproducción.
+func (ch *SlashCommandRouter) QueuePageHandler(h func(*discordgo.Session, *discordgo.InteractionCreate)) *SlashCommandRouter {
+	ch.queuePageHandler = h
+	return ch
+}
+
+// SearchResultHandler establece el manejador para el menú de resultados del comando "search".
+func (ch *SlashCommandRouter) SearchResultHandler(h func(*discordgo.Session, *discordgo.InteractionCreate)) *SlashCommandRouter {
+	ch.searchResultHandler = h
+	return ch
+}
+
+// PlayAutocompleteHandler establece el manejador que sugiere canciones para la opción input de los comandos "play" y "playnext".
+func (ch *SlashCommandRouter) PlayAutocompleteHandler(h func(context.Context, *discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption)) *SlashCommandRouter {
+	ch.playAutocompleteHandler = h
+	return ch
+}
+
+// PlayAttachmentHandler establece el manejador del comando del menú contextual de los mensajes que reproduce sus archivos de audio.
+func (ch *SlashCommandRouter) PlayAttachmentHandler(h func(context.Context, *discordgo.Session, *discordgo.InteractionCreate)) *SlashCommandRouter {
+	ch.playAttachmentHandler = h
+	return ch
+}
+
+// AuthorizeHandler establece el manejador que decide si el miembro puede usar un subcomando.
+// Se ejecuta antes de cada subcomando y, si devuelve false, el subcomando no se ejecuta.
+func (ch *SlashCommandRouter) AuthorizeHandler(h func(*discordgo.Session, *discordgo.InteractionCreate, *discordgo.ApplicationCommandInteractionDataOption) bool) *SlashCommandRouter {
+	ch.authorizeHandler = h
+	return ch
+}
+
 // GetCommandHandlers devuelve los manejadores de los comandos de barra oblicua.
 func (ch *SlashCommandRouter) GetCommandHandlers() map[string]func(context.Context, *discordgo.Session, *discordgo.InteractionCreate) {
 	return map[string]func(context.Context, *discordgo.Session, *discordgo.InteractionCreate){
 		ch.commandPrefix: func(ctx context.Context, s *discordgo.Session, ic *discordgo.InteractionCreate) {
 			options := ic.ApplicationCommandData().Options
 			option := options[0]
+			if ch.authorizeHandler != nil && !ch.authorizeHandler(s, ic, option) {
+				return
+			}
 
 			switch option.Name {
 			case "play":
 				ch.playHandler(ctx, s, ic, option)
+			case "playnext":
+				ch.playNextHandler(ctx, s, ic, option)
+			case "search":
+				ch.searchHandler(ctx, s, ic, option)
 			case "stop":
 				ch.stopHandler(s, ic, option)
 			case "list":
@@ -86,6 +221,48 @@ func (ch *SlashCommandRouter) GetCommandHandlers() map[string]func(context.Conte
 				ch.removeHandler(s, ic, option)
 			case "playing":
 				ch.playingNowHandler(s, ic, option)
+			case "pause":
+				ch.pauseHandler(s, ic, option)
+			case "resume":
+				ch.resumeHandler(s, ic, option)
+			case "seek":
+				ch.seekHandler(s, ic, option)
+			case "loop":
+				ch.loopHandler(s, ic, option)
+			case "volume":
+				ch.volumeHandler(s, ic, option)
+			case "autoplay":
+				ch.autoplayHandler(s, ic, option)
+			case "history":
+				ch.historyHandler(s, ic, option)
+			case "previous":
+				ch.previousHandler(s, ic, option)
+			case "shuffle":
+				ch.shuffleHandler(s, ic, option)
+			case "move":
+				ch.moveHandler(s, ic, option)
+			}
+		},
+		PlayAttachmentCommand: ch.playAttachmentHandler,
+	}
+}
+
+// GetAutocompleteHandlers devuelve los manejadores del autocompletado de los comandos de barra oblicua.
+// Las sugerencias no se autorizan porque no ejecutan nada: el permiso se revisa al usar el comando.
+func (ch *SlashCommandRouter) GetAutocompleteHandlers() map[string]func(context.Context, *discordgo.Session, *discordgo.InteractionCreate) {
+	return map[string]func(context.Context, *discordgo.Session, *discordgo.InteractionCreate){
+		ch.commandPrefix: func(ctx context.Context, s *discordgo.Session, ic *discordgo.InteractionCreate) {
+			options := ic.ApplicationCommandData().Options
+			if len(options) == 0 {
+				return
+			}
+			option := options[0]
+
+			switch option.Name {
+			case "play", "playnext":
+				if ch.playAutocompleteHandler != nil {
+					ch.playAutocompleteHandler(ctx, s, ic, option)
+				}
 			}
 		},
 	}
@@ -94,12 +271,23 @@ func (ch *SlashCommandRouter) GetCommandHandlers() map[string]func(context.Conte
 // GetComponentHandlers devuelve los manejadores de los componentes.
 func (ch *SlashCommandRouter) GetComponentHandlers() map[string]func(*discordgo.Session, *discordgo.InteractionCreate) {
 	return map[string]func(*discordgo.Session, *discordgo.InteractionCreate){
-		"add_song_playlist": ch.addSongOrPlaylistHandler,
+		"add_song_playlist":            ch.addSongOrPlaylistHandler,
+		"add_song_playlist_next":       ch.addSongOrPlaylistHandler,
+		voice.PlayerControlPauseResume: ch.playerControlHandler,
+		voice.PlayerControlSkip:        ch.playerControlHandler,
+		voice.PlayerControlStop:        ch.playerControlHandler,
+		voice.PlayerControlLoop:        ch.playerControlHandler,
+		voice.PlayerControlShuffle:     ch.playerControlHandler,
+		voice.PlayerControlQueue:       ch.playerControlHandler,
+		QueuePagePrevious:              ch.queuePageHandler,
+		QueuePageNext:                  ch.queuePageHandler,
+		SearchResultSelect:             ch.searchResultHandler,
 	}
 }
 
 // GetSlashCommands devuelve los comandos de barra oblicua.
 func (ch *SlashCommandRouter) GetSlashCommands() []*discordgo.ApplicationCommand {
+	minVolume := float64(voice.MinVolume)
 	return []*discordgo.ApplicationCommand{
 		{
 			Name:        ch.commandPrefix,
@@ -109,11 +297,43 @@ func (ch *SlashCommandRouter) GetSlashCommands() []*discordgo.ApplicationCommand
 					Type:        discordgo.ApplicationCommandOptionSubCommand,
 					Name:        "play",
 					Description: "Agregar una canción a la lista de reproducción",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:         discordgo.ApplicationCommandOptionString,
+							Name:         "input",
+							Description:  "URL o nombre de la pista",
+							Autocomplete: true,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionAttachment,
+							Name:        "attachment",
+							Description: "Archivo de audio a reproducir, en lugar de input",
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "playnext",
+					Description: "Agregar una canción para que suene a continuación",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:         discordgo.ApplicationCommandOptionString,
+							Name:         "input",
+							Description:  "URL o nombre de la pista",
+							Required:     true,
+							Autocomplete: true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "search",
+					Description: "Buscar una canción y elegir cuál agregar entre los resultados",
 					Options: []*discordgo.ApplicationCommandOption{
 						{
 							Type:        discordgo.ApplicationCommandOptionString,
-							Name:        "input",
-							Description: "URL o nombre de la pista",
+							Name:        "query",
+							Description: "Nombre de la pista a buscar",
 							Required:    true,
 						},
 					},
@@ -151,7 +371,114 @@ func (ch *SlashCommandRouter) GetSlashCommands() []*discordgo.ApplicationCommand
 					Name:        "playing",
 					Description: "Obtener la canción que se está reproduciendo actualmente",
 				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "pause",
+					Description: "Pausar la canción actual",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "resume",
+					Description: "Reanudar la canción pausada",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "seek",
+					Description: "Saltar a una posición de la canción actual",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "position",
+							Description: "Posición en formato mm:ss",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "loop",
+					Description: "Cambiar el modo de repetición",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "mode",
+							Description: "Modo de repetición",
+							Required:    true,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{Name: "Desactivado", Value: "off"},
+								{Name: "Canción actual", Value: "track"},
+								{Name: "Lista de reproducción", Value: "queue"},
+							},
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "volume",
+					Description: "Cambiar el volumen de reproducción",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "level",
+							Description: "Volumen en porcentaje (0-200)",
+							Required:    true,
+							MinValue:    &minVolume,
+							MaxValue:    voice.MaxVolume,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "autoplay",
+					Description: "Agregar canciones relacionadas cuando la lista de reproducción se vacía",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionBoolean,
+							Name:        "enabled",
+							Description: "Activar o desactivar la reproducción automática",
+							Required:    true,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "history",
+					Description: "Ver las canciones reproducidas recientemente",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "previous",
+					Description: "Volver a reproducir la última canción a continuación de la actual",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "shuffle",
+					Description: "Mezclar la lista de reproducción",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "move",
+					Description: "Mover una canción a otra posición de la lista de reproducción",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "from",
+							Description: "Posición actual de la canción",
+							Required:    true,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "to",
+							Description: "Nueva posición de la canción",
+							Required:    true,
+						},
+					},
+				},
 			},
 		},
+		{
+			Type: discordgo.MessageApplicationCommand,
+			Name: PlayAttachmentCommand,
+		},
 	}
 }
@@ -7,15 +7,23 @@ import (
 	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
 	"go.uber.org/zap"
 	"io"
+	"sync"
 	"time"
 )
 
 type DCAStreamer interface {
 	StreamDCAData(ctx context.Context, dca io.Reader, opusChan chan<- []byte, positionCallback func(position time.Duration)) error
+	// Pause retiene la entrega de frames sin perder la posición del lector.
+	Pause()
+	// Resume reanuda la entrega de frames desde donde se pausó.
+	Resume()
 }
 
 type DCAStreamerImpl struct {
-	logger logging.Logger
+	logger   logging.Logger
+	mu       sync.Mutex
+	paused   bool          // Indica si la entrega de frames está retenida.
+	resumeCh chan struct{} // Se cierra cuando se reanuda la transmisión.
 }
 
 const (
@@ -30,7 +38,52 @@ func NewDCAStreamerImpl(logger logging.Logger) *DCAStreamerImpl {
 	}
 }
 
+// Pause retiene la entrega de frames. El lector DCA no se consume mientras la transmisión está en pausa.
+func (d *DCAStreamerImpl) Pause() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.paused {
+		return
+	}
+	d.paused = true
+	d.resumeCh = make(chan struct{})
+}
+
+// Resume reanuda la entrega de frames si la transmisión estaba en pausa.
+func (d *DCAStreamerImpl) Resume() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.paused {
+		return
+	}
+	d.paused = false
+	close(d.resumeCh)
+}
+
+// waitIfPaused bloquea mientras la transmisión está en pausa. Devuelve false si el contexto se cancela durante la espera.
+func (d *DCAStreamerImpl) waitIfPaused(ctx context.Context) bool {
+	d.mu.Lock()
+	if !d.paused {
+		d.mu.Unlock()
+		return true
+	}
+	resumeCh := d.resumeCh
+	d.mu.Unlock()
+
+	select {
+	case <-resumeCh:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
 func (d *DCAStreamerImpl) StreamDCAData(ctx context.Context, dca io.Reader, opusChan chan<- []byte, positionCallback func(position time.Duration)) error {
+	// La pausa no debe trasladarse a la siguiente canción.
+	defer d.Resume()
+
 	var opuslen int16
 	framesSent := 0
 	positionChan := make(chan int)
@@ -44,6 +97,10 @@ func (d *DCAStreamerImpl) StreamDCAData(ctx context.Context, dca io.Reader, opus
 	}()
 
 	for {
+		if !d.waitIfPaused(ctx) {
+			return nil
+		}
+
 		err := binary.Read(dca, binary.LittleEndian, &opuslen)
 
 		if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
@@ -146,3 +146,57 @@ func TestStreamDCAData_ReturnsNilOnContextCancellation(t *testing.T) {
 		t.Errorf("StreamDCAData returned an unexpected error: %v", err)
 	}
 }
+
+func TestStreamDCAData_HoldsFramesWhilePaused(t *testing.T) {
+	mockLogger := new(logging.MockLogger)
+	clientDCA := NewDCAStreamerImpl(mockLogger)
+	dca := bytes.NewReader([]byte{0x02, 0x00, 0x01, 0x02, 0x02, 0x00, 0x03, 0x04})
+	opusChan := make(chan []byte, 2)
+	mockLogger.On("Error", "Error EOF o EOF inesperado encontrado durante la transmisión de datos DCA:", mock.AnythingOfType("[]zapcore.Field")).Return()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientDCA.Pause()
+	done := make(chan error)
+	go func() {
+		done <- clientDCA.StreamDCAData(ctx, dca, opusChan, nil)
+	}()
+
+	select {
+	case <-opusChan:
+		t.Fatal("No se deberían entregar frames mientras la transmisión está en pausa")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	clientDCA.Resume()
+
+	if actual := <-opusChan; !bytes.Equal([]byte{0x01, 0x02}, actual) {
+		t.Errorf("Expected %v, got %v", []byte{0x01, 0x02}, actual)
+	}
+	if actual := <-opusChan; !bytes.Equal([]byte{0x03, 0x04}, actual) {
+		t.Errorf("Expected %v, got %v", []byte{0x03, 0x04}, actual)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("StreamDCAData returned an unexpected error: %v", err)
+	}
+}
+
+func TestStreamDCAData_ReturnsNilWhenCancelledWhilePaused(t *testing.T) {
+	mockLogger := new(logging.MockLogger)
+	clientDCA := NewDCAStreamerImpl(mockLogger)
+	dca := bytes.NewReader([]byte{0x02, 0x00, 0x01, 0x02})
+	opusChan := make(chan []byte, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	clientDCA.Pause()
+	cancel()
+
+	err := clientDCA.StreamDCAData(ctx, dca, opusChan, nil)
+	if err != nil {
+		t.Errorf("StreamDCAData returned an unexpected error: %v", err)
+	}
+	if len(opusChan) != 0 {
+		t.Error("No se deberían haber entregado frames")
+	}
+}
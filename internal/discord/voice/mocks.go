@@ -54,3 +54,11 @@ func (m *MockDCAStreamer) StreamDCAData(ctx context.Context, dca io.Reader, opus
 	args := m.Called(ctx, dca, opusChan, positionCallback)
 	return args.Error(0)
 }
+
+func (m *MockDCAStreamer) Pause() {
+	m.Called()
+}
+
+func (m *MockDCAStreamer) Resume() {
+	m.Called()
+}
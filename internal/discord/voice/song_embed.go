@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/Tomas-vilte/GoMusicBot/internal/utils"
 	"github.com/bwmarrin/discordgo"
+	"path/filepath"
 )
 
 // GeneratePlayingSongEmbed un mensaje embed para mostrar que se está agregando una canción a la cola de reproducción.
@@ -12,26 +13,71 @@ func GeneratePlayingSongEmbed(message *PlayMessage) *discordgo.MessageEmbed {
 		return nil // Retornamos nil si message o message.Song es nil
 	}
 
-	progressBar := generateProgressBar(float64(message.Position)/float64(message.Song.Duration), 20)
+	var description string
+	switch {
+	case message.Song.Live:
+		description = fmt.Sprintf("🔴 En vivo · %s", utils.FmtDuration(message.Position))
+	case message.Song.Duration <= 0:
+		// Sin duración no se puede dibujar el progreso.
+		description = utils.FmtDuration(message.Position)
+	default:
+		progressBar := generateProgressBar(float64(message.Position)/float64(message.Song.Duration), 20)
+		description = fmt.Sprintf("%s\n%s / %s", progressBar, utils.FmtDuration(message.Position), utils.FmtDuration(message.Song.Duration))
+	}
+	if message.StreamTitle != "" {
+		description = fmt.Sprintf("🎙️ %s\n%s", message.StreamTitle, description)
+	}
+	if message.Paused {
+		description = fmt.Sprintf("⏸️ En pausa\n%s", description)
+	}
 
 	embed := &discordgo.MessageEmbed{
 		Title:       message.Song.GetHumanName(),
-		Description: fmt.Sprintf("%s\n%s / %s", progressBar, utils.FmtDuration(message.Position), utils.FmtDuration(message.Song.Duration)),
+		Description: description,
 	}
 	if message.Song.ThumbnailURL != nil {
 		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{
 			URL: *message.Song.ThumbnailURL,
 		}
+	} else if message.Song.ThumbnailFile != "" {
+		// La portada local va adjunta al mensaje, y el embed la referencia por el nombre del adjunto.
+		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{
+			URL: "attachment://" + filepath.Base(message.Song.ThumbnailFile),
+		}
 	}
 
 	if message.Song.RequestedBy != nil {
+		text := fmt.Sprintf("Solicitado por: %v", *message.Song.RequestedBy)
+		if message.Song.UploadedBy != "" {
+			text += fmt.Sprintf(" · Subido por: %s", message.Song.UploadedBy)
+		}
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: text,
+		}
+	} else if message.Song.Autoplay {
 		embed.Footer = &discordgo.MessageEmbedFooter{
-			Text: fmt.Sprintf("Solicitado por: %v", *message.Song.RequestedBy),
+			Text: "📻 Elegida por la reproducción automática",
 		}
 	}
 	return embed
 }
 
+// GenerateSkipVoteEmbed genera un mensaje embed con el recuento de votos para saltar la canción actual.
+func GenerateSkipVoteEmbed(song *Song, votes, required int, skipped bool) *discordgo.MessageEmbed {
+	if song == nil {
+		return nil
+	}
+
+	description := fmt.Sprintf("🗳️ %d/%d votos para saltar", votes, required)
+	if skipped {
+		description = fmt.Sprintf("⏭️ Canción omitida por votación (%d/%d)", votes, required)
+	}
+	return &discordgo.MessageEmbed{
+		Title:       song.GetHumanName(),
+		Description: description,
+	}
+}
+
 func generateProgressBar(progress float64, length int) string {
 	played := int(progress * float64(length))
 
@@ -46,3 +92,92 @@ func generateProgressBar(progress float64, length int) string {
 	}
 	return progressBar
 }
+
+// IDs de los botones del mensaje de reproducción.
+const (
+	PlayerControlPauseResume = "player_pause_resume"
+	PlayerControlSkip        = "player_skip"
+	PlayerControlStop        = "player_stop"
+	PlayerControlLoop        = "player_loop"
+	PlayerControlShuffle     = "player_shuffle"
+	PlayerControlQueue       = "player_queue"
+)
+
+// GeneratePlayerControls genera los botones del mensaje de reproducción según el estado del reproductor.
+// Cuando la canción terminó, todos los botones quedan deshabilitados.
+func GeneratePlayerControls(message *PlayMessage) []discordgo.MessageComponent {
+	if message == nil || message.Song == nil {
+		return nil
+	}
+
+	pauseResume := discordgo.Button{
+		CustomID: PlayerControlPauseResume,
+		Label:    "Pausar",
+		Style:    discordgo.SecondaryButton,
+		Emoji:    &discordgo.ComponentEmoji{Name: "⏸️"},
+		Disabled: message.Finished,
+	}
+	if message.Paused {
+		pauseResume.Label = "Reanudar"
+		pauseResume.Style = discordgo.SuccessButton
+		pauseResume.Emoji = &discordgo.ComponentEmoji{Name: "▶️"}
+	}
+
+	loop := discordgo.Button{
+		CustomID: PlayerControlLoop,
+		Label:    "Repetir",
+		Style:    discordgo.SecondaryButton,
+		Emoji:    &discordgo.ComponentEmoji{Name: "🔁"},
+		Disabled: message.Finished,
+	}
+	switch message.LoopMode {
+	case LoopModeTrack:
+		loop.Label = "Repitiendo canción"
+		loop.Style = discordgo.PrimaryButton
+		loop.Emoji = &discordgo.ComponentEmoji{Name: "🔂"}
+	case LoopModeQueue:
+		loop.Label = "Repitiendo lista"
+		loop.Style = discordgo.PrimaryButton
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				pauseResume,
+				discordgo.Button{
+					CustomID: PlayerControlSkip,
+					Label:    "Saltar",
+					Style:    discordgo.SecondaryButton,
+					Emoji:    &discordgo.ComponentEmoji{Name: "⏭️"},
+					Disabled: message.Finished,
+				},
+				discordgo.Button{
+					CustomID: PlayerControlStop,
+					Label:    "Detener",
+					Style:    discordgo.DangerButton,
+					Emoji:    &discordgo.ComponentEmoji{Name: "⏹️"},
+					Disabled: message.Finished,
+				},
+			},
+		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				loop,
+				discordgo.Button{
+					CustomID: PlayerControlShuffle,
+					Label:    "Mezclar",
+					Style:    discordgo.SecondaryButton,
+					Emoji:    &discordgo.ComponentEmoji{Name: "🔀"},
+					Disabled: message.Finished || message.QueueLength < 2,
+				},
+				discordgo.Button{
+					CustomID: PlayerControlQueue,
+					Label:    "Ver lista",
+					Style:    discordgo.SecondaryButton,
+					Emoji:    &discordgo.ComponentEmoji{Name: "📜"},
+					Disabled: message.Finished,
+				},
+			},
+		},
+	}
+}
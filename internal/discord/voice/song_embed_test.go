@@ -2,6 +2,7 @@ package voice
 
 import (
 	"github.com/Tomas-vilte/GoMusicBot/internal/utils"
+	"github.com/bwmarrin/discordgo"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -92,3 +93,159 @@ func TestGeneratePlayingSongEmbed_NilRequestedBy(t *testing.T) {
 	assert.NotNil(t, embed)
 	assert.Nil(t, embed.Footer)
 }
+
+func TestGeneratePlayingSongEmbed_Paused(t *testing.T) {
+	// Configuración
+	message := &PlayMessage{
+		Song: &Song{
+			Title:    "Canción de prueba",
+			Duration: 180 * time.Second,
+		},
+		Position: 60 * time.Second,
+		Paused:   true,
+	}
+
+	// Ejecución
+	embed := GeneratePlayingSongEmbed(message)
+
+	// Verificación
+	assert.NotNil(t, embed)
+	assert.Contains(t, embed.Description, "⏸️ En pausa")
+	assert.Contains(t, embed.Description, "01:00 / 03:00")
+}
+
+func TestGeneratePlayingSongEmbed_Autoplay(t *testing.T) {
+	// Configuración
+	message := &PlayMessage{
+		Song: &Song{
+			Title:    "Canción de prueba",
+			Duration: 180 * time.Second,
+			Autoplay: true,
+		},
+	}
+
+	// Ejecución
+	embed := GeneratePlayingSongEmbed(message)
+
+	// Verificación
+	assert.NotNil(t, embed.Footer)
+	assert.Contains(t, embed.Footer.Text, "reproducción automática")
+}
+
+func TestGeneratePlayingSongEmbed_UploadedBy(t *testing.T) {
+	// Configuración
+	requestedBy := "Fito"
+	message := &PlayMessage{
+		Song: &Song{
+			Title:       "demo.mp3",
+			RequestedBy: &requestedBy,
+			UploadedBy:  "Charly",
+		},
+	}
+
+	// Ejecución
+	embed := GeneratePlayingSongEmbed(message)
+
+	// Verificación
+	assert.NotNil(t, embed.Footer)
+	assert.Equal(t, "Solicitado por: Fito · Subido por: Charly", embed.Footer.Text)
+}
+
+func TestGeneratePlayingSongEmbed_Live(t *testing.T) {
+	// Configuración
+	message := &PlayMessage{
+		Song: &Song{
+			Title: "Radio Nacional Rock",
+			Live:  true,
+		},
+		Position:    90 * time.Second,
+		StreamTitle: "Los Piojos - Tan solo",
+	}
+
+	// Ejecución
+	embed := GeneratePlayingSongEmbed(message)
+
+	// Verificación
+	assert.NotNil(t, embed)
+	assert.Contains(t, embed.Description, "🎙️ Los Piojos - Tan solo")
+	assert.Contains(t, embed.Description, "🔴 En vivo · 01:30")
+	assert.NotContains(t, embed.Description, "⬛") // Sin barra de progreso
+}
+
+func TestGenerateSkipVoteEmbed(t *testing.T) {
+	// Configuración
+	song := &Song{Title: "Canción de prueba"}
+
+	// Ejecución
+	pending := GenerateSkipVoteEmbed(song, 1, 3, false)
+	skipped := GenerateSkipVoteEmbed(song, 3, 3, true)
+
+	// Verificación
+	assert.Equal(t, "🗳️ 1/3 votos para saltar", pending.Description)
+	assert.Equal(t, "⏭️ Canción omitida por votación (3/3)", skipped.Description)
+	assert.Nil(t, GenerateSkipVoteEmbed(nil, 0, 1, false))
+}
+
+// playerButtons devuelve los botones del mensaje de reproducción indexados por su ID.
+func playerButtons(components []discordgo.MessageComponent) map[string]discordgo.Button {
+	buttons := make(map[string]discordgo.Button)
+	for _, row := range components {
+		for _, component := range row.(discordgo.ActionsRow).Components {
+			button := component.(discordgo.Button)
+			buttons[button.CustomID] = button
+		}
+	}
+	return buttons
+}
+
+func TestGeneratePlayerControls_Playing(t *testing.T) {
+	// Configuración
+	message := &PlayMessage{
+		Song:        &Song{Title: "Canción de prueba"},
+		LoopMode:    LoopModeTrack,
+		QueueLength: 1,
+	}
+
+	// Ejecución
+	buttons := playerButtons(GeneratePlayerControls(message))
+
+	// Verificación
+	assert.Len(t, buttons, 6)
+	assert.Equal(t, "Pausar", buttons[PlayerControlPauseResume].Label)
+	assert.Equal(t, "Repitiendo canción", buttons[PlayerControlLoop].Label)
+	assert.Equal(t, discordgo.PrimaryButton, buttons[PlayerControlLoop].Style)
+	assert.True(t, buttons[PlayerControlShuffle].Disabled)
+	assert.False(t, buttons[PlayerControlSkip].Disabled)
+}
+
+func TestGeneratePlayerControls_PausedAndFinished(t *testing.T) {
+	// Configuración
+	paused := &PlayMessage{Song: &Song{Title: "Canción de prueba"}, Paused: true, QueueLength: 2}
+	finished := &PlayMessage{Song: &Song{Title: "Canción de prueba"}, Finished: true, QueueLength: 2}
+
+	// Ejecución
+	pausedButtons := playerButtons(GeneratePlayerControls(paused))
+	finishedButtons := playerButtons(GeneratePlayerControls(finished))
+
+	// Verificación
+	assert.Equal(t, "Reanudar", pausedButtons[PlayerControlPauseResume].Label)
+	assert.False(t, pausedButtons[PlayerControlShuffle].Disabled)
+	for id, button := range finishedButtons {
+		assert.True(t, button.Disabled, id)
+	}
+	assert.Nil(t, GeneratePlayerControls(&PlayMessage{}))
+}
+
+func TestLoopMode_Next(t *testing.T) {
+	assert.Equal(t, LoopModeTrack, LoopModeOff.Next())
+	assert.Equal(t, LoopModeQueue, LoopModeTrack.Next())
+	assert.Equal(t, LoopModeOff, LoopModeQueue.Next())
+}
+
+func TestSong_GetVolume(t *testing.T) {
+	assert.Equal(t, DefaultVolume, (&Song{}).GetVolume())
+	volume := 150
+	assert.Equal(t, 150, (&Song{Volume: &volume}).GetVolume())
+	muted := 0
+	assert.Equal(t, 0, (&Song{Volume: &muted}).GetVolume())
+}
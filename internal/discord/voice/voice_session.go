@@ -13,12 +13,19 @@ type (
 		JoinVoiceChannel(channelID string) error
 		LeaveVoiceChannel() error
 		SendAudio(ctx context.Context, reader io.Reader, positionCallback func(time.Duration)) error
+		Pause()
+		Resume()
 	}
 
 	// PlayMessage es el mensaje que se enviará al canal de texto para mostrar la canción que se está reproduciendo actualmente.
 	PlayMessage struct {
-		Song     *Song
-		Position time.Duration
+		Song        *Song
+		Position    time.Duration
+		Paused      bool
+		LoopMode    LoopMode // Modo de repetición, mostrado en el botón de repetición.
+		QueueLength int      // Cantidad de canciones en la lista de reproducción, sin contar la actual.
+		Finished    bool     // Indica que la canción dejó de sonar y los botones ya no tienen efecto.
+		StreamTitle string   // Título que anuncia la transmisión en vivo, si lo informa.
 	}
 
 	// Song representa una canción que se puede reproducir.
@@ -26,20 +33,76 @@ type (
 		Type          string
 		Title         string
 		URL           string
+		Channel       string // Nombre del canal que publicó la canción.
 		Playable      bool
 		ThumbnailURL  *string
+		ThumbnailFile string // Ruta de una portada local, que se adjunta al mensaje de reproducción si no hay ThumbnailURL.
 		Duration      time.Duration
 		StartPosition time.Duration
 		RequestedBy   *string
+		RequesterID   string // ID del usuario de Discord que agregó la canción.
+		UploadedBy    string // Nombre de quien subió el archivo adjunto de la canción, si no es quien la pidió.
+		Volume        *int   // Volumen de transmisión en porcentaje, lo establece el reproductor al pedir los datos de audio. Sin establecer equivale a DefaultVolume.
+		Autoplay      bool   // Indica si la canción fue elegida por la reproducción automática.
+		Live          bool   // Indica que la canción es una transmisión en vivo, sin duración ni fin conocidos.
 	}
 
 	// PlayedSong representa una canción que ha sido reproducida.
 	PlayedSong struct {
 		Song
-		Position time.Duration
+		Position    time.Duration
+		StreamTitle string // Título que anuncia la transmisión en vivo, si lo informa.
 	}
+
+	// StreamTitler lo implementan los datos de audio de las transmisiones que anuncian qué están pasando,
+	// como las radios Icecast y Shoutcast.
+	StreamTitler interface {
+		StreamTitle() string
+	}
+
+	// LoopMode representa el modo de repetición del reproductor.
+	LoopMode string
+)
+
+const (
+	// LoopModeOff reproduce cada canción una sola vez.
+	LoopModeOff LoopMode = "off"
+	// LoopModeTrack repite la canción actual.
+	LoopModeTrack LoopMode = "track"
+	// LoopModeQueue vuelve a agregar cada canción al final de la lista de reproducción.
+	LoopModeQueue LoopMode = "queue"
 )
 
+const (
+	// DefaultVolume es el volumen normal, sin ganancia aplicada.
+	DefaultVolume = 100
+	// MinVolume es el volumen mínimo permitido, que silencia el audio.
+	MinVolume = 0
+	// MaxVolume es el volumen máximo permitido.
+	MaxVolume = 200
+)
+
+// IsValid indica si el modo de repetición es uno de los modos conocidos.
+func (m LoopMode) IsValid() bool {
+	switch m {
+	case LoopModeOff, LoopModeTrack, LoopModeQueue:
+		return true
+	}
+	return false
+}
+
+// Next devuelve el modo de repetición siguiente, en el orden desactivado, canción actual y lista de reproducción.
+func (m LoopMode) Next() LoopMode {
+	switch m {
+	case LoopModeTrack:
+		return LoopModeQueue
+	case LoopModeQueue:
+		return LoopModeOff
+	default:
+		return LoopModeTrack
+	}
+}
+
 // GetHumanName devuelve el nombre humano legible de la canción.
 func (s *Song) GetHumanName() string {
 	if s.Title != "" {
@@ -47,3 +110,24 @@ func (s *Song) GetHumanName() string {
 	}
 	return s.URL
 }
+
+// GetRequesterKey identifica a quien pidió la canción. Usa el ID del usuario y, si no está, su nombre.
+// Las canciones que nadie pidió, como las de la reproducción automática, devuelven una cadena vacía.
+func (s *Song) GetRequesterKey() string {
+	if s.RequesterID != "" {
+		return s.RequesterID
+	}
+	if s.RequestedBy != nil {
+		return *s.RequestedBy
+	}
+	return ""
+}
+
+// GetVolume devuelve el volumen de transmisión de la canción. Las canciones sin volumen establecido, como las
+// guardadas antes de que existiera el campo, se transmiten con DefaultVolume.
+func (s *Song) GetVolume() int {
+	if s.Volume == nil {
+		return DefaultVolume
+	}
+	return *s.Volume
+}
@@ -150,3 +150,25 @@ func (session *ChatSessionImpl) SendAudio(ctx context.Context, reader io.Reader,
 
 	return nil
 }
+
+// Pause retiene el envío de audio sin descartar el flujo actual.
+func (session *ChatSessionImpl) Pause() {
+	session.logger.Info("Pausando el envío de audio...")
+	session.DCAStreamer.Pause()
+	if session.voiceConnection != nil {
+		if err := session.voiceConnection.Speaking(false); err != nil {
+			session.logger.Error("Error al dejar de hablar: ", zap.Error(err))
+		}
+	}
+}
+
+// Resume reanuda el envío de audio desde la posición en la que se pausó.
+func (session *ChatSessionImpl) Resume() {
+	session.logger.Info("Reanudando el envío de audio...")
+	if session.voiceConnection != nil {
+		if err := session.voiceConnection.Speaking(true); err != nil {
+			session.logger.Error("Error al comenzar a hablar: ", zap.Error(err))
+		}
+	}
+	session.DCAStreamer.Resume()
+}
@@ -21,6 +21,9 @@ import (
 	"time"
 )
 
+// maxVolume es el volumen máximo permitido, en porcentaje. Coincide con el máximo del comando de volumen.
+const maxVolume = 200
+
 var (
 	AudioApplicationVoip     AudioApplication = "voip"     // Aplicación de audio para voz sobre IP (VoIP)
 	AudioApplicationAudio    AudioApplication = "audio"    // Aplicación de audio general
@@ -28,7 +31,7 @@ var (
 
 	// StdEncodeOptions Opciones predeterminadas para la codificación de audio.
 	StdEncodeOptions = &EncodeOptions{
-		Volume:           256,                   // Nivel de volumen (256 es el valor normal)
+		Volume:           100,                   // Nivel de volumen (100 es el valor normal)
 		Channels:         2,                     // Número de canales de audio (por ej. 2 para estéreo)
 		FrameRate:        48000,                 // Frecuencia de muestreo del audio en Hz (por ej. 48000 Hz)
 		FrameDuration:    20,                    // Duración del marco de audio en ms (puede ser 20, 40 o 60 ms)
@@ -48,7 +51,7 @@ type (
 
 	// EncodeOptions contiene las opciones de configuración para la codificación de audio.
 	EncodeOptions struct {
-		Volume           int              // Nivel de volumen del audio (256 = normal)
+		Volume           int              // Nivel de volumen del audio en porcentaje (100 = normal)
 		Channels         int              // Número de canales de audio
 		FrameRate        int              // Frecuencia de muestreo del audio (por ej. 48000 Hz)
 		FrameDuration    int              // Duración del marco de audio en ms (puede ser 20, 40 o 60 ms)
@@ -80,19 +83,19 @@ type (
 
 	// EncodeSession representa una sesión de codificación de audio.
 	EncodeSession struct {
-		sync.Mutex                     // Mutex para sincronización concurrente
-		options      *EncodeOptions    // Opciones de codificación
-		pipeReader   io.Reader         // Lector para el pipe
-		filePath     string            // Ruta del archivo a codificar
-		running      bool              // Indica si la sesión está en ejecución
-		started      time.Time         // Hora de inicio de la sesión
-		frameChannel chan *Frame       // Canal para transmitir los marcos de audio
-		process      *os.Process       // Proceso de codificación
-		lastStats    *EncodeStats      // Últimas estadísticas de codificación
-		lastFrame    int               // Último marco procesado
-		err          error             // Error que ocurrió durante la codificación
-		ffmpegOutput string            // Salida del proceso ffmpeg
-		logging      logging.ZapLogger // Logger para registros
+		sync.Mutex                  // Mutex para sincronización concurrente
+		options      *EncodeOptions // Opciones de codificación
+		pipeReader   io.Reader      // Lector para el pipe
+		filePath     string         // Ruta del archivo a codificar
+		running      bool           // Indica si la sesión está en ejecución
+		started      time.Time      // Hora de inicio de la sesión
+		frameChannel chan *Frame    // Canal para transmitir los marcos de audio
+		process      *os.Process    // Proceso de codificación
+		lastStats    *EncodeStats   // Últimas estadísticas de codificación
+		lastFrame    int            // Último marco procesado
+		err          error          // Error que ocurrió durante la codificación
+		ffmpegOutput string         // Salida del proceso ffmpeg
+		logging      logging.Logger // Logger para registros
 		// Búfer para almacenar bytes no leídos (cuadros incompletos), utilizado para implementar io.Reader
 		buf bytes.Buffer
 	}
@@ -108,7 +111,7 @@ func (e *EncodeOptions) PCMFrameLen() int {
 // Devuelve un error si alguna opción es inválida.
 func (e *EncodeOptions) Validate() error {
 	// Verifica que el volumen esté en el rango permitido.
-	if e.Volume < 0 || e.Volume > 512 {
+	if e.Volume < 0 || e.Volume > maxVolume {
 		return ErrInvalidVolume
 	}
 
@@ -142,7 +145,7 @@ func (e *EncodeOptions) Validate() error {
 
 // EncodeMem crea una nueva sesión de codificación en memoria usando las opciones proporcionadas.
 // Valida las opciones antes de iniciar la sesión. Devuelve la sesión de codificación o un error si las opciones son inválidas.
-func EncodeMem(r io.Reader, options *EncodeOptions, ctx context.Context) (session *EncodeSession, err error) {
+func EncodeMem(r io.Reader, options *EncodeOptions, ctx context.Context, logger logging.Logger) (session *EncodeSession, err error) {
 	err = options.Validate()
 	if err != nil {
 		return
@@ -152,6 +155,7 @@ func EncodeMem(r io.Reader, options *EncodeOptions, ctx context.Context) (sessio
 		options:      options,
 		pipeReader:   r,
 		frameChannel: make(chan *Frame, options.BufferedFrames),
+		logging:      logger,
 	}
 	go session.run(ctx)
 	return
@@ -163,18 +167,12 @@ func EncodeMem(r io.Reader, options *EncodeOptions, ctx context.Context) (sessio
 // - path: Ruta del archivo de entrada que se va a codificar.
 // - options: Opciones de codificación que definen cómo se debe procesar el audio.
 // - ctx: Contexto que permite cancelar la operación o pasar información adicional.
+// - logger: Logger para los registros de la sesión.
 //
 // Retorna:
 // - session: Una instancia de EncodeSession que representa la sesión de codificación creada.
 // - err: Un error, si ocurre durante la creación de la sesión o la validación de opciones.
-func EncodeFile(path string, options *EncodeOptions, ctx context.Context) (session *EncodeSession, err error) {
-	// Inicializa un nuevo logger utilizando Zap.
-	logger, err := logging.NewZapLogger(false)
-	if err != nil {
-		// Retorna nil y el error si la creación del logger falla.
-		return nil, err
-	}
-
+func EncodeFile(path string, options *EncodeOptions, ctx context.Context, logger logging.Logger) (session *EncodeSession, err error) {
 	// Valida las opciones de codificación proporcionadas.
 	err = options.Validate()
 	if err != nil {
@@ -187,7 +185,7 @@ func EncodeFile(path string, options *EncodeOptions, ctx context.Context) (sessi
 		options:      options,                                   // Opciones de codificación a usar.
 		filePath:     path,                                      // Ruta del archivo a codificar.
 		frameChannel: make(chan *Frame, options.BufferedFrames), // Canal para transmitir marcos de audio.
-		logging:      *logger,                                   // Logger para registros de la sesión.
+		logging:      logger,                                    // Logger para registros de la sesión.
 	}
 
 	// Inicia la ejecución de la sesión en una goroutine separada.
@@ -3,6 +3,7 @@ package encoder
 import (
 	"context"
 	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
 	"io"
 	"os"
 	"testing"
@@ -10,7 +11,11 @@ import (
 
 func TestEncode(t *testing.T) {
 	ctx := context.Background()
-	session, err := EncodeFile("deadpool-bye-bye.ogg", StdEncodeOptions, ctx)
+	logger, err := logging.NewZapLogger(false)
+	if err != nil {
+		t.Fatal("Fallo al crear el logger:", err)
+	}
+	session, err := EncodeFile("deadpool-bye-bye.ogg", StdEncodeOptions, ctx, logger)
 	if err != nil {
 		t.Fatal("Fallo al crear la session de encoding:", err)
 	}
@@ -3,7 +3,7 @@ package encoder
 import "errors"
 
 var (
-	ErrInvalidVolume           = errors.New("volumen fuera de los límites (0-512)")
+	ErrInvalidVolume           = errors.New("volumen fuera de los límites (0-200)")
 	ErrInvalidFrameDuration    = errors.New("duración de fotograma inválida")
 	ErrInvalidPacketLoss       = errors.New("porcentaje de pérdida de paquetes inválido")
 	ErrInvalidAudioApplication = errors.New("aplicación de audio inválida")
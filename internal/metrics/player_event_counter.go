@@ -0,0 +1,31 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// PlayerEventCounter cuenta los eventos del ciclo de vida de las canciones de los reproductores.
+type PlayerEventCounter struct {
+	counterVec *prometheus.CounterVec
+}
+
+func NewPlayerEventCounter() *PlayerEventCounter {
+	return &PlayerEventCounter{
+		counterVec: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "player_events_total",
+			Help: "Número total de eventos de los reproductores, etiquetados por evento",
+		},
+			[]string{"event"},
+		),
+	}
+}
+
+func (c *PlayerEventCounter) Describe(ch chan<- *prometheus.Desc) {
+	c.counterVec.Describe(ch)
+}
+
+func (c *PlayerEventCounter) Collect(ch chan<- prometheus.Metric) {
+	c.counterVec.Collect(ch)
+}
+
+func (c *PlayerEventCounter) Inc(labels ...string) {
+	c.counterVec.WithLabelValues(labels...).Inc()
+}
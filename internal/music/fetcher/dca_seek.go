@@ -0,0 +1,33 @@
+package fetcher
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// dcaFrameDuration es la duración de audio que contiene cada frame DCA.
+const dcaFrameDuration = 20 * time.Millisecond
+
+// skipDCAFrames descarta del lector los frames DCA correspondientes a la posición indicada.
+// Si los datos terminan antes de alcanzar la posición, el lector queda al final sin devolver error.
+func skipDCAFrames(r io.Reader, position time.Duration) error {
+	frames := int(position / dcaFrameDuration)
+	for i := 0; i < frames; i++ {
+		var opusLen int16
+		if err := binary.Read(r, binary.LittleEndian, &opusLen); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil
+			}
+			return fmt.Errorf("error al leer la longitud del frame DCA: %w", err)
+		}
+		if _, err := io.CopyN(io.Discard, r, int64(opusLen)); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("error al descartar el frame DCA: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package fetcher
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestSkipDCAFrames(t *testing.T) {
+	frames := []byte{
+		0x02, 0x00, 0x01, 0x02,
+		0x01, 0x00, 0x03,
+		0x03, 0x00, 0x04, 0x05, 0x06,
+	}
+
+	t.Run("SkipsFramesUpToPosition", func(t *testing.T) {
+		reader := bytes.NewReader(frames)
+
+		err := skipDCAFrames(reader, 40*time.Millisecond)
+		assert.NoError(t, err)
+
+		rest, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x03, 0x00, 0x04, 0x05, 0x06}, rest)
+	})
+
+	t.Run("ZeroPositionKeepsReader", func(t *testing.T) {
+		reader := bytes.NewReader(frames)
+
+		err := skipDCAFrames(reader, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, len(frames), reader.Len())
+	})
+
+	t.Run("PositionBeyondEnd", func(t *testing.T) {
+		reader := bytes.NewReader(frames)
+
+		err := skipDCAFrames(reader, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, reader.Len())
+	})
+}
@@ -0,0 +1,97 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/decoder"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/encoder"
+	"io"
+	"mccoy.space/g/ogg"
+)
+
+const (
+	// dcaSampleRate es la frecuencia de muestreo de los frames DCA.
+	dcaSampleRate = 48000
+	// dcaFrameSamples es la cantidad de muestras por canal de cada frame DCA.
+	dcaFrameSamples = dcaSampleRate / 50
+	// oggSerial identifica el único flujo lógico del Ogg que se arma con los frames DCA.
+	oggSerial = 0x53455330
+)
+
+// withVolume aplica el volumen de la canción a los datos DCA del lector. Los datos guardados en la caché y en S3
+// están sin ganancia, así que para otro volumen se vuelven a codificar con el encoder: los frames se envuelven en
+// un Ogg Opus que ffmpeg puede leer y el encoder aplica el volumen de sus opciones.
+func (s *YoutubeFetcher) withVolume(ctx context.Context, song *voice.Song, dca io.Reader) (io.Reader, error) {
+	if song.GetVolume() == voice.DefaultVolume {
+		return dca, nil
+	}
+
+	oggReader, oggWriter := io.Pipe()
+	go func() {
+		oggWriter.CloseWithError(writeOggOpus(oggWriter, dca))
+	}()
+
+	options := *encoder.StdEncodeOptions
+	// El reproductor lee los cuadros de opus sin el encabezado de metadatos de DCA.
+	options.RawOutput = true
+	options.Volume = song.GetVolume()
+
+	session, err := encoder.EncodeMem(oggReader, &options, ctx, s.Logger)
+	if err != nil {
+		oggReader.Close()
+		return nil, fmt.Errorf("error al aplicar el volumen al audio: %w", err)
+	}
+	// Si ffmpeg termina antes de leer todo, cerrar el pipe libera a la goroutine que arma el Ogg.
+	context.AfterFunc(ctx, func() { oggReader.Close() })
+	return session, nil
+}
+
+// writeOggOpus escribe en w los frames DCA de dca como un flujo Ogg Opus estéreo de 48 kHz, con un frame por página.
+func writeOggOpus(w io.Writer, dca io.Reader) error {
+	oggEncoder := ogg.NewEncoder(oggSerial, w)
+
+	head := make([]byte, 19)
+	copy(head, "OpusHead")
+	head[8] = 1 // Versión.
+	head[9] = 2 // Canales.
+	binary.LittleEndian.PutUint32(head[12:], dcaSampleRate)
+	if err := oggEncoder.EncodeBOS(0, [][]byte{head}); err != nil {
+		return fmt.Errorf("error al escribir el encabezado Ogg: %w", err)
+	}
+
+	vendor := "GoMusicBot"
+	tags := make([]byte, 8+4+len(vendor)+4)
+	copy(tags, "OpusTags")
+	binary.LittleEndian.PutUint32(tags[8:], uint32(len(vendor)))
+	copy(tags[12:], vendor)
+	if err := oggEncoder.Encode(0, [][]byte{tags}); err != nil {
+		return fmt.Errorf("error al escribir las etiquetas Ogg: %w", err)
+	}
+
+	// Se lee un frame por adelantado para escribir el último con la marca de fin de flujo.
+	var granule int64
+	frame, err := decoder.DecodeFrame(dca)
+	if err != nil {
+		if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("error al leer el frame DCA: %w", err)
+		}
+		return oggEncoder.EncodeEOS(0, nil)
+	}
+	for {
+		next, nextErr := decoder.DecodeFrame(dca)
+		granule += dcaFrameSamples
+		if nextErr != nil {
+			if !errors.Is(nextErr, io.EOF) && !errors.Is(nextErr, io.ErrUnexpectedEOF) {
+				return fmt.Errorf("error al leer el frame DCA: %w", nextErr)
+			}
+			return oggEncoder.EncodeEOS(granule, [][]byte{frame})
+		}
+		if err := oggEncoder.Encode(granule, [][]byte{frame}); err != nil {
+			return fmt.Errorf("error al escribir el frame Ogg: %w", err)
+		}
+		frame = next
+	}
+}
@@ -0,0 +1,117 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"github.com/Tomas-vilte/GoMusicBot/internal/decoder"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/Tomas-vilte/GoMusicBot/internal/storage/s3_audio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"mccoy.space/g/ogg"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestWriteOggOpus(t *testing.T) {
+	frames := []byte{
+		0x02, 0x00, 0x01, 0x02,
+		0x01, 0x00, 0x03,
+	}
+
+	var out bytes.Buffer
+	assert.NoError(t, writeOggOpus(&out, bytes.NewReader(frames)))
+
+	// Se esperan las páginas de encabezado, de etiquetas y una por frame.
+	var pages []ogg.Page
+	oggDecoder := ogg.NewDecoder(&out)
+	for {
+		page, err := oggDecoder.Decode()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.NoError(t, err)
+		pages = append(pages, page)
+	}
+
+	assert.Len(t, pages, 4)
+	assert.Equal(t, "OpusHead", string(pages[0].Packets[0][:8]))
+	assert.Equal(t, "OpusTags", string(pages[1].Packets[0][:8]))
+	assert.Equal(t, [][]byte{{0x01, 0x02}}, pages[2].Packets)
+	assert.Equal(t, int64(960), pages[2].Granule)
+	assert.Equal(t, [][]byte{{0x03}}, pages[3].Packets)
+	assert.Equal(t, int64(1920), pages[3].Granule)
+}
+
+func TestYoutubeFetcher_GetDCAData_Volume(t *testing.T) {
+	cached := []byte{0x01, 0x00, 0x07}
+
+	t.Run("DefaultVolumeReadsCacheAsIs", func(t *testing.T) {
+		audioCache := new(MockAudioCaching)
+		executor := new(MockCommandExecutor)
+		fetcher := &YoutubeFetcher{Logger: new(logging.MockLogger), audioCache: audioCache, CommandExecutor: executor}
+		song := &voice.Song{Title: "Song", URL: youtubeWatchURLPrefix + "song"}
+
+		audioCache.On("Get", song.URL).Return(cached, true)
+
+		reader, err := fetcher.GetDCAData(context.Background(), song)
+		assert.NoError(t, err)
+		data, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, cached, data)
+		executor.AssertNotCalled(t, "ExecuteCommand", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("OtherVolumeReencodesCache", func(t *testing.T) {
+		if _, err := exec.LookPath("ffmpeg"); err != nil {
+			t.Skip("ffmpeg no está instalado")
+		}
+
+		// Se usan los primeros cinco segundos de la canción de prueba del decoder, sin su encabezado de metadatos.
+		const frameCount = 250
+		file, err := os.Open("../../decoder/deadpool-bye-bye.dca")
+		assert.NoError(t, err)
+		defer file.Close()
+		dca := decoder.NewDecoder(file)
+		assert.NoError(t, dca.ReadMetadata())
+		var cachedSong bytes.Buffer
+		for i := 0; i < frameCount; i++ {
+			frame, err := dca.OpusFrame()
+			assert.NoError(t, err)
+			assert.NoError(t, binary.Write(&cachedSong, binary.LittleEndian, int16(len(frame))))
+			cachedSong.Write(frame)
+		}
+
+		audioCache := new(MockAudioCaching)
+		uploader := new(s3_audio.MockS3Uploader)
+		logger := new(logging.MockLogger)
+		logger.On("Debug", mock.Anything, mock.Anything).Return()
+		logger.On("Error", mock.Anything, mock.Anything).Return()
+		fetcher := &YoutubeFetcher{Logger: logger, audioCache: audioCache, S3Uploader: uploader}
+		volume := 50
+		song := &voice.Song{Title: "Song", URL: youtubeWatchURLPrefix + "song", Volume: &volume}
+
+		audioCache.On("Get", song.URL).Return(cachedSong.Bytes(), true)
+
+		reader, err := fetcher.GetDCAData(context.Background(), song)
+		assert.NoError(t, err)
+
+		// ffmpeg vuelve a codificar el audio, así que se comparan la cantidad de frames y no los bytes.
+		frames := 0
+		for {
+			_, err := decoder.DecodeFrame(reader)
+			if err != nil {
+				assert.ErrorIs(t, err, io.EOF)
+				break
+			}
+			frames++
+		}
+		assert.InDelta(t, frameCount, frames, 5)
+		uploader.AssertNotCalled(t, "FileExists", mock.Anything, mock.Anything)
+	})
+}
@@ -80,11 +80,35 @@ func (m *MockYouTubeService) SearchVideoID(ctx context.Context, searchTerm strin
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockYouTubeService) SearchVideoIDs(ctx context.Context, searchTerm string, maxResults int64) ([]string, error) {
+	args := m.Called(ctx, searchTerm, maxResults)
+	videoIDs, _ := args.Get(0).([]string)
+	return videoIDs, args.Error(1)
+}
+
 func (m *MockYouTubeService) GetVideoDetails(ctx context.Context, videoID string) (*youtube.Video, error) {
 	args := m.Called(ctx, videoID)
 	return args.Get(0).(*youtube.Video), args.Error(1)
 }
 
+func (m *MockYouTubeService) GetPlaylistVideoIDs(ctx context.Context, playlistID string, maxResults int) ([]string, error) {
+	args := m.Called(ctx, playlistID, maxResults)
+	videoIDs, _ := args.Get(0).([]string)
+	return videoIDs, args.Error(1)
+}
+
+func (m *MockYouTubeService) GetVideosDetails(ctx context.Context, videoIDs []string) ([]*youtube.Video, error) {
+	args := m.Called(ctx, videoIDs)
+	videos, _ := args.Get(0).([]*youtube.Video)
+	return videos, args.Error(1)
+}
+
+func (m *MockYouTubeService) SearchRelatedVideoIDs(ctx context.Context, videoID string, exclude []string, maxResults int64) ([]string, error) {
+	args := m.Called(ctx, videoID, exclude, maxResults)
+	videoIDs, _ := args.Get(0).([]string)
+	return videoIDs, args.Error(1)
+}
+
 // MockCommandExecutor es un mock de CommandExecutor usando testify
 type MockCommandExecutor struct {
 	mock.Mock
@@ -0,0 +1,87 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"go.uber.org/zap"
+)
+
+// defaultMaxPlaylistSize es la cantidad máxima de canciones que se importan de una lista de reproducción si no se configuró otra.
+const defaultMaxPlaylistSize = 100
+
+// PlaylistFromURL devuelve el ID de la lista de reproducción de una URL de YouTube con el parámetro list=,
+// y el ID del video elegido si la URL también indica uno.
+func PlaylistFromURL(input string) (playlistID, videoID string, ok bool) {
+	videoID, query, ok := parseYoutubeURL(input)
+	if !ok {
+		return "", "", false
+	}
+	playlistID = query.Get("list")
+	return playlistID, videoID, playlistID != ""
+}
+
+// resolvePlaylist obtiene las canciones de una lista de reproducción. Si la URL también indicaba un video,
+// ese video queda primero para que la opción de agregar una sola canción agregue el que se pidió.
+// Si la lista no se puede obtener, como pasa con las mezclas que arma YouTube, se usa solo el video indicado.
+func (s *YoutubeFetcher) resolvePlaylist(ctx context.Context, playlistID, videoID string) ([]*voice.Song, error) {
+	songs, err := s.LookupPlaylist(ctx, playlistID, s.maxPlaylistSize)
+	if err != nil {
+		if videoID == "" {
+			return nil, err
+		}
+		s.Logger.Info("No se pudo obtener la lista de reproducción, se usa solo el video", zap.Error(err), zap.String("playlistID", playlistID))
+		return s.LookupSongs(ctx, videoID)
+	}
+
+	if videoID != "" {
+		for i, song := range songs {
+			if id, ok := VideoIDFromURL(song.URL); ok && id == videoID {
+				reordered := make([]*voice.Song, 0, len(songs))
+				reordered = append(reordered, song)
+				reordered = append(reordered, songs[:i]...)
+				songs = append(reordered, songs[i+1:]...)
+				break
+			}
+		}
+	}
+	return songs, nil
+}
+
+// LookupPlaylist obtiene hasta maxSongs canciones de una lista de reproducción de YouTube, en el orden de la lista.
+// Se descartan los videos privados o eliminados y los que no se pueden reproducir. Cada canción se guarda en la
+// caché de búsquedas, por lo que las canciones devueltas no deben modificarse.
+func (s *YoutubeFetcher) LookupPlaylist(ctx context.Context, playlistID string, maxSongs int) ([]*voice.Song, error) {
+	videoIDs, err := s.YoutubeService.GetPlaylistVideoIDs(ctx, playlistID, maxSongs)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener la lista de reproducción: %w", err)
+	}
+
+	videos, err := s.YoutubeService.GetVideosDetails(ctx, videoIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener detalles de los videos de la lista de reproducción: %w", err)
+	}
+
+	// YouTube no garantiza el orden de los detalles, así que se respeta el orden de la lista.
+	songsByID := make(map[string]*voice.Song, len(videos))
+	for _, video := range videos {
+		song := s.songFromVideo(youtubeWatchURLPrefix+video.Id, video)
+		s.Cache.Set(song.URL, []*voice.Song{song})
+		songsByID[video.Id] = song
+	}
+
+	songs := make([]*voice.Song, 0, len(songsByID))
+	for _, id := range videoIDs {
+		song, ok := songsByID[id]
+		if !ok || !song.Playable {
+			s.Logger.Info("Descartando video de la lista de reproducción", zap.String("playlistID", playlistID), zap.String("videoID", id))
+			continue
+		}
+		songs = append(songs, song)
+	}
+
+	if len(songs) == 0 {
+		return nil, fmt.Errorf("la lista de reproducción %s no tiene canciones que se puedan reproducir", playlistID)
+	}
+	return songs, nil
+}
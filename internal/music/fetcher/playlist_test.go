@@ -0,0 +1,75 @@
+package fetcher
+
+import (
+	"context"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/api/youtube/v3"
+	"testing"
+	"time"
+)
+
+func TestPlaylistFromURL(t *testing.T) {
+	tests := []struct {
+		input      string
+		playlistID string
+		videoID    string
+		ok         bool
+	}{
+		{"https://www.youtube.com/playlist?list=PL123", "PL123", "", true},
+		{"https://www.youtube.com/watch?v=abc&list=PL123&index=2", "PL123", "abc", true},
+		{"https://music.youtube.com/watch?v=abc&list=PL123", "PL123", "abc", true},
+		{"https://youtu.be/abc?list=PL123", "PL123", "abc", true},
+		{"https://www.youtube.com/watch?v=abc", "", "", false},
+		{"https://example.com/?list=PL123", "", "", false},
+		{"la bersuit", "", "", false},
+	}
+
+	for _, tt := range tests {
+		playlistID, videoID, ok := PlaylistFromURL(tt.input)
+		assert.Equal(t, tt.ok, ok, tt.input)
+		if tt.ok {
+			assert.Equal(t, tt.playlistID, playlistID, tt.input)
+			assert.Equal(t, tt.videoID, videoID, tt.input)
+		}
+	}
+}
+
+func TestVideoIDFromURL_IgnoresOtherParameters(t *testing.T) {
+	videoID, ok := VideoIDFromURL(youtubeWatchURLPrefix + "abc&list=PL123")
+	assert.True(t, ok)
+	assert.Equal(t, "abc", videoID)
+}
+
+func TestYoutubeFetcher_LookupPlaylist(t *testing.T) {
+	video := func(id, broadcast string) *youtube.Video {
+		return &youtube.Video{
+			Id:             id,
+			Snippet:        &youtube.VideoSnippet{Title: "Tema " + id, LiveBroadcastContent: broadcast, Thumbnails: &youtube.ThumbnailDetails{Default: &youtube.Thumbnail{Url: "https://i.ytimg.com/" + id}}},
+			ContentDetails: &youtube.VideoContentDetails{Duration: "PT3M"},
+		}
+	}
+
+	youtubeService := new(MockYouTubeService)
+	cacheManager := new(MockCacheManager)
+	logger := new(logging.MockLogger)
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	cacheManager.On("Set", mock.Anything, mock.Anything).Return()
+	fetcher := &YoutubeFetcher{Logger: logger, Cache: cacheManager, YoutubeService: youtubeService}
+
+	youtubeService.On("GetPlaylistVideoIDs", mock.Anything, "PL123", 5).Return([]string{"a", "privado", "live", "b"}, nil)
+	youtubeService.On("GetVideosDetails", mock.Anything, []string{"a", "privado", "live", "b"}).
+		Return([]*youtube.Video{video("b", "none"), video("live", "live"), video("a", "none")}, nil)
+
+	songs, err := fetcher.LookupPlaylist(context.Background(), "PL123", 5)
+	assert.NoError(t, err)
+	assert.Len(t, songs, 2)
+	assert.Equal(t, youtubeWatchURLPrefix+"a", songs[0].URL)
+	assert.Equal(t, youtubeWatchURLPrefix+"b", songs[1].URL)
+	assert.Equal(t, "https://i.ytimg.com/b", *songs[1].ThumbnailURL)
+	assert.Equal(t, 3*time.Minute, songs[1].Duration)
+
+	youtubeService.AssertExpectations(t)
+	cacheManager.AssertNumberOfCalls(t, "Set", 3)
+}
@@ -0,0 +1,59 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/Tomas-vilte/GoMusicBot/internal/storage/s3_audio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestYoutubeFetcher_Prefetch(t *testing.T) {
+	song := &voice.Song{Title: "Song", URL: youtubeWatchURLPrefix + "song"}
+
+	t.Run("AlreadyCached", func(t *testing.T) {
+		audioCache := new(MockAudioCaching)
+		uploader := new(s3_audio.MockS3Uploader)
+		fetcher := &YoutubeFetcher{Logger: new(logging.MockLogger), audioCache: audioCache, S3Uploader: uploader}
+
+		audioCache.On("Get", song.URL).Return([]byte{0x01}, true)
+
+		assert.NoError(t, fetcher.Prefetch(context.Background(), song))
+		audioCache.AssertExpectations(t)
+		uploader.AssertNotCalled(t, "FileExists", mock.Anything, mock.Anything)
+	})
+
+	t.Run("LoadsFromS3", func(t *testing.T) {
+		audioCache := new(MockAudioCaching)
+		uploader := new(s3_audio.MockS3Uploader)
+		logger := new(logging.MockLogger)
+		logger.On("Info", mock.Anything, mock.Anything).Return()
+		fetcher := &YoutubeFetcher{Logger: logger, audioCache: audioCache, S3Uploader: uploader}
+
+		data := []byte{0x01, 0x00, 0x02}
+		audioCache.On("Get", song.URL).Return(nil, false)
+		uploader.On("FileExists", mock.Anything, "audio/Song.dca").Return(true, nil)
+		uploader.On("DownloadDCA", mock.Anything, "audio/Song.dca").Return(bytes.NewReader(data), nil)
+		audioCache.On("Set", song.URL, data).Return()
+
+		assert.NoError(t, fetcher.Prefetch(context.Background(), song))
+		audioCache.AssertExpectations(t)
+		uploader.AssertExpectations(t)
+	})
+
+	t.Run("S3Error", func(t *testing.T) {
+		audioCache := new(MockAudioCaching)
+		uploader := new(s3_audio.MockS3Uploader)
+		fetcher := &YoutubeFetcher{Logger: new(logging.MockLogger), audioCache: audioCache, S3Uploader: uploader}
+
+		audioCache.On("Get", song.URL).Return(nil, false)
+		uploader.On("FileExists", mock.Anything, "audio/Song.dca").Return(false, errors.New("s3"))
+
+		assert.Error(t, fetcher.Prefetch(context.Background(), song))
+		audioCache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,67 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"go.uber.org/zap"
+	"strings"
+)
+
+const (
+	youtubeWatchURLPrefix = "https://www.youtube.com/watch?v="
+	// relatedSearchResults es la cantidad de videos relacionados que se piden a YouTube por búsqueda.
+	relatedSearchResults = 10
+)
+
+// ErrNoRelatedSongs indica que no se encontró ninguna canción relacionada que se pueda reproducir.
+var ErrNoRelatedSongs = errors.New("no se encontraron canciones relacionadas")
+
+// VideoIDFromURL devuelve el ID del video de YouTube de una URL con el formato que arma LookupSongs.
+// Se ignoran los demás parámetros de la URL, como la lista de reproducción o el tiempo de inicio.
+func VideoIDFromURL(url string) (string, bool) {
+	videoID, ok := strings.CutPrefix(url, youtubeWatchURLPrefix)
+	videoID, _, _ = strings.Cut(videoID, "&")
+	return videoID, ok && videoID != ""
+}
+
+// GetRelatedSong busca una canción relacionada con la canción indicada para la reproducción automática.
+// Se descartan la canción indicada, las canciones cuya URL esté en exclude y las que no se pueden reproducir.
+func (s *YoutubeFetcher) GetRelatedSong(ctx context.Context, song *voice.Song, exclude []string) (*voice.Song, error) {
+	videoID, ok := VideoIDFromURL(song.URL)
+	if !ok {
+		return nil, fmt.Errorf("la canción no es un video de YouTube: %s", song.URL)
+	}
+
+	// Las canciones recientes que no son de YouTube no pueden aparecer entre los resultados.
+	excludedIDs := make([]string, 0, len(exclude))
+	for _, url := range exclude {
+		if id, ok := VideoIDFromURL(url); ok {
+			excludedIDs = append(excludedIDs, id)
+		}
+	}
+
+	videoIDs, err := s.YoutubeService.SearchRelatedVideoIDs(ctx, videoID, excludedIDs, relatedSearchResults)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar videos relacionados: %w", err)
+	}
+
+	for _, id := range videoIDs {
+		songs, err := s.LookupSongs(ctx, id)
+		if err != nil {
+			s.Logger.Info("Descartando video relacionado", zap.String("videoID", id), zap.Error(err))
+			continue
+		}
+		if len(songs) == 0 || !songs[0].Playable {
+			continue
+		}
+
+		// Se usa una copia para no marcar la canción guardada en la caché de búsquedas.
+		related := *songs[0]
+		related.Autoplay = true
+		return &related, nil
+	}
+
+	return nil, ErrNoRelatedSongs
+}
@@ -0,0 +1,65 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestYoutubeFetcher_GetRelatedSong(t *testing.T) {
+	seed := &voice.Song{Title: "Seed", URL: youtubeWatchURLPrefix + "seed"}
+
+	t.Run("ExcludesRecentSongsAndSkipsUnplayable", func(t *testing.T) {
+		youtubeService := new(MockYouTubeService)
+		cacheManager := new(MockCacheManager)
+		logger := new(logging.MockLogger)
+		logger.On("Info", mock.Anything, mock.Anything).Return()
+		fetcher := &YoutubeFetcher{Logger: logger, Cache: cacheManager, YoutubeService: youtubeService}
+
+		youtubeService.On("SearchRelatedVideoIDs", mock.Anything, "seed", []string{"played"}, int64(relatedSearchResults)).Return([]string{"live", "fresh"}, nil)
+		cacheManager.On("Get", youtubeWatchURLPrefix+"live").Return([]*voice.Song{{Title: "Live", URL: youtubeWatchURLPrefix + "live", Playable: false}})
+		cached := &voice.Song{Title: "Fresh", URL: youtubeWatchURLPrefix + "fresh", Playable: true}
+		cacheManager.On("Get", youtubeWatchURLPrefix+"fresh").Return([]*voice.Song{cached})
+
+		song, err := fetcher.GetRelatedSong(context.Background(), seed, []string{youtubeWatchURLPrefix + "played", "https://radio.example/stream"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Fresh", song.Title)
+		assert.True(t, song.Autoplay)
+		assert.False(t, cached.Autoplay)
+
+		youtubeService.AssertExpectations(t)
+		cacheManager.AssertExpectations(t)
+	})
+
+	t.Run("NoPlayableSongs", func(t *testing.T) {
+		youtubeService := new(MockYouTubeService)
+		fetcher := &YoutubeFetcher{Logger: new(logging.MockLogger), Cache: new(MockCacheManager), YoutubeService: youtubeService}
+
+		youtubeService.On("SearchRelatedVideoIDs", mock.Anything, "seed", []string{}, int64(relatedSearchResults)).Return(nil, nil)
+
+		song, err := fetcher.GetRelatedSong(context.Background(), seed, nil)
+		assert.ErrorIs(t, err, ErrNoRelatedSongs)
+		assert.Nil(t, song)
+	})
+
+	t.Run("SearchError", func(t *testing.T) {
+		youtubeService := new(MockYouTubeService)
+		fetcher := &YoutubeFetcher{Logger: new(logging.MockLogger), Cache: new(MockCacheManager), YoutubeService: youtubeService}
+
+		youtubeService.On("SearchRelatedVideoIDs", mock.Anything, "seed", []string{}, int64(relatedSearchResults)).Return(nil, errors.New("quota"))
+
+		_, err := fetcher.GetRelatedSong(context.Background(), seed, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("NotYouTubeSong", func(t *testing.T) {
+		fetcher := &YoutubeFetcher{Logger: new(logging.MockLogger)}
+
+		_, err := fetcher.GetRelatedSong(context.Background(), &voice.Song{URL: "https://example.com/song.mp3"}, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,40 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"go.uber.org/zap"
+)
+
+// ErrNoSearchResults indica que la búsqueda no encontró ninguna canción que se pueda reproducir.
+var ErrNoSearchResults = errors.New("no se encontraron canciones para la búsqueda")
+
+// SearchSongs busca en YouTube hasta maxResults canciones para el término de búsqueda, en el orden en que las devuelve YouTube.
+// Se descartan los videos cuyos detalles no se pudieron obtener y los que no se pueden reproducir.
+// Las canciones pueden estar compartidas con la caché de búsquedas, por lo que no deben modificarse.
+func (s *YoutubeFetcher) SearchSongs(ctx context.Context, searchTerm string, maxResults int64) ([]*voice.Song, error) {
+	videoIDs, err := s.YoutubeService.SearchVideoIDs(ctx, searchTerm, maxResults)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar videos en YouTube: %w", err)
+	}
+
+	results := make([]*voice.Song, 0, len(videoIDs))
+	for _, id := range videoIDs {
+		songs, err := s.LookupSongs(ctx, id)
+		if err != nil {
+			s.Logger.Info("Descartando resultado de búsqueda", zap.String("videoID", id), zap.Error(err))
+			continue
+		}
+		if len(songs) == 0 || !songs[0].Playable {
+			continue
+		}
+		results = append(results, songs[0])
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNoSearchResults
+	}
+	return results, nil
+}
@@ -0,0 +1,59 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestYoutubeFetcher_SearchSongs(t *testing.T) {
+	t.Run("SkipsUnplayableSongs", func(t *testing.T) {
+		youtubeService := new(MockYouTubeService)
+		cacheManager := new(MockCacheManager)
+		logger := new(logging.MockLogger)
+		logger.On("Info", mock.Anything, mock.Anything).Return()
+		fetcher := &YoutubeFetcher{Logger: logger, Cache: cacheManager, YoutubeService: youtubeService}
+
+		youtubeService.On("SearchVideoIDs", mock.Anything, "tema", int64(3)).Return([]string{"cover", "live", "lyrics"}, nil)
+		cacheManager.On("Get", youtubeWatchURLPrefix+"cover").Return([]*voice.Song{{Title: "Cover", Playable: true}})
+		cacheManager.On("Get", youtubeWatchURLPrefix+"live").Return([]*voice.Song{{Title: "Live", Playable: false}})
+		cacheManager.On("Get", youtubeWatchURLPrefix+"lyrics").Return([]*voice.Song{{Title: "Lyrics", Playable: true}})
+
+		songs, err := fetcher.SearchSongs(context.Background(), "tema", 3)
+		assert.NoError(t, err)
+		assert.Len(t, songs, 2)
+		assert.Equal(t, "Cover", songs[0].Title)
+		assert.Equal(t, "Lyrics", songs[1].Title)
+
+		youtubeService.AssertExpectations(t)
+		cacheManager.AssertExpectations(t)
+	})
+
+	t.Run("NoPlayableSongs", func(t *testing.T) {
+		youtubeService := new(MockYouTubeService)
+		cacheManager := new(MockCacheManager)
+		logger := new(logging.MockLogger)
+		logger.On("Info", mock.Anything, mock.Anything).Return()
+		fetcher := &YoutubeFetcher{Logger: logger, Cache: cacheManager, YoutubeService: youtubeService}
+
+		youtubeService.On("SearchVideoIDs", mock.Anything, "tema", int64(1)).Return([]string{"live"}, nil)
+		cacheManager.On("Get", youtubeWatchURLPrefix+"live").Return([]*voice.Song{{Title: "Live", Playable: false}})
+
+		_, err := fetcher.SearchSongs(context.Background(), "tema", 1)
+		assert.ErrorIs(t, err, ErrNoSearchResults)
+	})
+
+	t.Run("SearchError", func(t *testing.T) {
+		youtubeService := new(MockYouTubeService)
+		fetcher := &YoutubeFetcher{Logger: new(logging.MockLogger), Cache: new(MockCacheManager), YoutubeService: youtubeService}
+
+		youtubeService.On("SearchVideoIDs", mock.Anything, "tema", int64(5)).Return(nil, errors.New("quota"))
+
+		_, err := fetcher.SearchSongs(context.Background(), "tema", 5)
+		assert.Error(t, err)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/Tomas-vilte/GoMusicBot/internal/services/providers"
 	"github.com/Tomas-vilte/GoMusicBot/internal/storage/s3_audio"
 	"go.uber.org/zap"
+	"google.golang.org/api/youtube/v3"
 	"io"
 	"os/exec"
 	"strconv"
@@ -23,6 +24,7 @@ type (
 	SongLooker interface {
 		LookupSongs(ctx context.Context, input string) ([]*voice.Song, error)
 		SearchYouTubeVideoID(ctx context.Context, searchTerm string) (string, error)
+		SearchSongs(ctx context.Context, searchTerm string, maxResults int64) ([]*voice.Song, error)
 	}
 
 	// YoutubeFetcher es un tipo que interactúa con YouTube para obtener metadatos y datos de audio.
@@ -33,6 +35,7 @@ type (
 		YoutubeService  providers.YouTubeService
 		CommandExecutor CommandExecutor
 		S3Uploader      s3_audio.Uploader
+		maxPlaylistSize int
 
 		// Esto es para uso temporal! Debido a que youtube pide oauth, ademas con esto podemos evitar baneamiento de IP
 		//username string
@@ -64,9 +67,18 @@ func NewYoutubeFetcher(logger logging.Logger, cache cache.Manager, youtubeServic
 		audioCache:      audioCache,
 		CommandExecutor: commandExecutor,
 		S3Uploader:      s3Upload,
+		maxPlaylistSize: defaultMaxPlaylistSize,
 	}
 }
 
+// WithMaxPlaylistSize establece la cantidad máxima de canciones que se importan de una lista de reproducción.
+func (s *YoutubeFetcher) WithMaxPlaylistSize(size int) *YoutubeFetcher {
+	if size > 0 {
+		s.maxPlaylistSize = size
+	}
+	return s
+}
+
 // LookupSongs busca canciones en YouTube según el término de búsqueda proporcionado en input.
 // Retorna una lista de objetos voice.Song que contienen metadatos de las canciones encontradas.
 func (s *YoutubeFetcher) LookupSongs(ctx context.Context, input string) ([]*voice.Song, error) {
@@ -84,24 +96,29 @@ func (s *YoutubeFetcher) LookupSongs(ctx context.Context, input string) ([]*voic
 		return nil, fmt.Errorf("error al obtener detalles del video")
 	}
 
+	songs := []*voice.Song{s.songFromVideo(videoURL, video)}
+
+	s.Cache.Set(videoURL, songs)
+	return songs, nil
+}
+
+// songFromVideo arma la canción con los detalles de un video de YouTube.
+func (s *YoutubeFetcher) songFromVideo(videoURL string, video *youtube.Video) *voice.Song {
 	duration, err := parseCustomDuration(video.ContentDetails.Duration)
 	if err != nil {
 		s.Logger.Error("Error al analizar la duracion: ", zap.Error(err))
 	}
 	thumbnailURL := video.Snippet.Thumbnails.Default.Url
 
-	song := &voice.Song{
-		Type:         "youtube_provider",
+	return &voice.Song{
+		Type:         YoutubeSongType,
 		Title:        video.Snippet.Title,
 		URL:          videoURL,
 		Playable:     video.Snippet.LiveBroadcastContent != "live",
 		ThumbnailURL: &thumbnailURL,
 		Duration:     duration,
+		Channel:      video.Snippet.ChannelTitle,
 	}
-	songs := []*voice.Song{song}
-
-	s.Cache.Set(videoURL, songs)
-	return songs, nil
 }
 
 func parseCustomDuration(durationStr string) (time.Duration, error) {
@@ -153,12 +170,19 @@ func parseCustomDuration(durationStr string) (time.Duration, error) {
 
 // GetDCAData obtiene los datos de audio de una canción en formato DCA.
 // Utiliza yt-dlp y ffmpeg para descargar el audio de YouTube y convertirlo al formato DCA esperado por Discord.
+// Si la canción tiene una posición de inicio, los datos comienzan en esa posición.
+// La caché y S3 guardan el audio sin ganancia; si la canción tiene otro volumen, se aplica al transmitirlos.
 // Retorna un io.Reader que permite leer los datos de audio y un posible error.
 func (s *YoutubeFetcher) GetDCAData(ctx context.Context, song *voice.Song) (io.Reader, error) {
 	key := fmt.Sprintf("audio/%s.dca", song.Title)
 	// Verificar si los datos de audio están en caché
 	if cachedData, ok := s.audioCache.Get(song.URL); ok {
-		return bytes.NewReader(cachedData), nil
+		reader := bytes.NewReader(cachedData)
+		if err := skipDCAFrames(reader, song.StartPosition); err != nil {
+			s.Logger.Error("Error al posicionar los datos DCA en caché", zap.Error(err))
+			return nil, fmt.Errorf("error al posicionar los datos DCA en caché: %w", err)
+		}
+		return s.withVolume(ctx, song, reader)
 	}
 
 	// Verificar si el archivo está en S3
@@ -178,45 +202,113 @@ func (s *YoutubeFetcher) GetDCAData(ctx context.Context, song *voice.Song) (io.R
 			s.Logger.Error("Error al descargar datos DCA desde S3", zap.Error(err))
 			return nil, fmt.Errorf("error al descargar datos DCA desde S3: %w", err)
 		}
+		if err := skipDCAFrames(audioReader, song.StartPosition); err != nil {
+			s.Logger.Error("Error al posicionar los datos DCA de S3", zap.Error(err))
+			return nil, fmt.Errorf("error al posicionar los datos DCA de S3: %w", err)
+		}
 	} else {
+		// Si la descarga no empieza desde el principio, no se cachea para no guardar audio incompleto.
+		if song.StartPosition > 0 {
+			return s.withVolume(ctx, song, s.streamWithoutCache(ctx, song))
+		}
+
 		// Crear un pipe para la transmisión progresiva de datos
 		reader, writer := io.Pipe()
 
 		go func() {
 			defer writer.Close()
 
-			// Buffer para almacenar los datos descargados y convertir en cache
-			var buffer bytes.Buffer
-			multiWriter := io.MultiWriter(writer, &buffer)
-
-			if err := s.downloadAndStreamAudio(ctx, song, multiWriter); err != nil {
+			if err := s.downloadAndCache(ctx, song, key, writer); err != nil {
 				s.Logger.Error("Error al descargar y transmitir audio", zap.Error(err))
 				writer.CloseWithError(err)
-				return
-			}
-			// Almacenar en cache
-			s.audioCache.Set(song.URL, buffer.Bytes())
-
-			// Subir a S3 si no existe
-			if err := s.S3Uploader.UploadDCA(ctx, &buffer, key); err != nil {
-				s.Logger.Error("Error al subir datos DCA a S3", zap.Error(err))
-				// No devolvemos error aquí para no afectar la operación principal
 			}
 		}()
 
 		audioReader = reader
 	}
 
-	return audioReader, nil
+	return s.withVolume(ctx, song, audioReader)
+}
+
+// Prefetch descarga los datos de audio de una canción a la caché de audio sin transmitirlos.
+// Si el contexto se cancela antes de terminar, no se guarda nada en la caché.
+func (s *YoutubeFetcher) Prefetch(ctx context.Context, song *voice.Song) error {
+	if _, ok := s.audioCache.Get(song.URL); ok {
+		return nil
+	}
+
+	// La caché siempre guarda la canción completa.
+	cacheSong := *song
+	cacheSong.StartPosition = 0
+
+	key := fmt.Sprintf("audio/%s.dca", song.Title)
+	exists, err := s.S3Uploader.FileExists(ctx, key)
+	if err != nil {
+		return fmt.Errorf("error al verificar la existencia del archivo en S3: %w", err)
+	}
+
+	if exists {
+		s.Logger.Info("Precargando datos DCA de S3", zap.String("key", song.Title))
+		reader, err := s.S3Uploader.DownloadDCA(ctx, key)
+		if err != nil {
+			return fmt.Errorf("error al descargar datos DCA desde S3: %w", err)
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return fmt.Errorf("error al leer datos DCA desde S3: %w", err)
+		}
+		s.audioCache.Set(song.URL, data)
+		return nil
+	}
+
+	s.Logger.Info("Precargando audio", zap.String("título", song.Title))
+	if err := s.downloadAndCache(ctx, &cacheSong, key, io.Discard); err != nil {
+		return fmt.Errorf("error al precargar audio: %w", err)
+	}
+	return nil
+}
+
+// downloadAndCache descarga el audio de la canción escribiéndolo en writer y, al terminar, lo guarda en la caché y en S3.
+func (s *YoutubeFetcher) downloadAndCache(ctx context.Context, song *voice.Song, key string, writer io.Writer) error {
+	// Buffer para almacenar los datos descargados y convertir en cache
+	var buffer bytes.Buffer
+	multiWriter := io.MultiWriter(writer, &buffer)
+
+	if err := s.downloadAndStreamAudio(ctx, song, multiWriter); err != nil {
+		return err
+	}
+	// Almacenar en cache
+	s.audioCache.Set(song.URL, buffer.Bytes())
+
+	// Subir a S3 si no existe
+	if err := s.S3Uploader.UploadDCA(ctx, &buffer, key); err != nil {
+		s.Logger.Error("Error al subir datos DCA a S3", zap.Error(err))
+		// No devolvemos error aquí para no afectar la operación principal
+	}
+	return nil
+}
+
+// streamWithoutCache descarga y transmite el audio de la canción sin guardarlo en la caché ni en S3.
+func (s *YoutubeFetcher) streamWithoutCache(ctx context.Context, song *voice.Song) io.Reader {
+	reader, writer := io.Pipe()
+
+	go func() {
+		defer writer.Close()
+
+		if err := s.downloadAndStreamAudio(ctx, song, writer); err != nil {
+			s.Logger.Error("Error al descargar y transmitir audio", zap.Error(err))
+			writer.CloseWithError(err)
+		}
+	}()
+
+	return reader
 }
 
 func (s *YoutubeFetcher) downloadAndStreamAudio(ctx context.Context, song *voice.Song, writer io.Writer) error {
 	ytArgs := []string{"-f", "bestaudio[ext=m4a]", "--audio-quality", "0", "-o", "-", "--force-overwrites", "--http-chunk-size", "100K", "--username", "oauth2", "--password", "''", song.URL}
-	ffmpegArgs := []string{"-i", "pipe:0", "-b:a", "192k", "-f", "s16le", "-ar", "48000", "-ac", "2", "pipe:1"}
-
 	cmd := s.CommandExecutor.ExecuteCommand(ctx, "sh", "-c", fmt.Sprintf("yt-dlp %s | ffmpeg %s | dca",
 		strings.Join(ytArgs, " "),
-		strings.Join(ffmpegArgs, " ")))
+		strings.Join(buildFFMPEGArgs(song), " ")))
 
 	stdoutPipe, err := cmd.StdoutPipe()
 	if err != nil {
@@ -262,6 +354,17 @@ func (s *YoutubeFetcher) downloadAndStreamAudio(ctx context.Context, song *voice
 	return nil
 }
 
+// buildFFMPEGArgs construye los argumentos de ffmpeg para convertir el audio de la canción a PCM. El audio se
+// descarga siempre sin ganancia; el volumen lo aplica withVolume.
+func buildFFMPEGArgs(song *voice.Song) []string {
+	args := []string{"-i", "pipe:0", "-b:a", "192k", "-f", "s16le", "-ar", "48000", "-ac", "2"}
+	if song.StartPosition > 0 {
+		// ffmpeg descarta el audio anterior a la posición de inicio.
+		args = append([]string{"-ss", strconv.FormatFloat(song.StartPosition.Seconds(), 'f', 3, 64)}, args...)
+	}
+	return append(args, "pipe:1")
+}
+
 func (s *YoutubeFetcher) SearchYouTubeVideoID(ctx context.Context, searchTerm string) (string, error) {
 	videoID, err := s.YoutubeService.SearchVideoID(ctx, searchTerm)
 	if err != nil {
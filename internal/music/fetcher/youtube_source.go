@@ -0,0 +1,58 @@
+package fetcher
+
+import (
+	"context"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"net/url"
+	"strings"
+)
+
+// YoutubeSongType es el tipo de las canciones de YouTube, con el que el registro de fuentes elige a YoutubeFetcher.
+const YoutubeSongType = "youtube_provider"
+
+// Name devuelve el tipo de las canciones que produce YoutubeFetcher.
+func (s *YoutubeFetcher) Name() string {
+	return YoutubeSongType
+}
+
+// CanHandle indica si la entrada es una URL de YouTube. Los nombres de canciones también se buscan en YouTube,
+// pero como fuente por defecto del registro y no porque YoutubeFetcher los reconozca.
+func (s *YoutubeFetcher) CanHandle(input string) bool {
+	_, _, ok := parseYoutubeURL(input)
+	return ok
+}
+
+// Resolve obtiene las canciones para la entrada: todas las de una lista de reproducción si la URL tiene el
+// parámetro list=, la del video si es la URL de un video, o la del primer resultado de buscar la entrada en YouTube.
+func (s *YoutubeFetcher) Resolve(ctx context.Context, input string) ([]*voice.Song, error) {
+	if playlistID, videoID, ok := PlaylistFromURL(input); ok {
+		return s.resolvePlaylist(ctx, playlistID, videoID)
+	}
+
+	videoID, _, ok := parseYoutubeURL(input)
+	if !ok || videoID == "" {
+		var err error
+		videoID, err = s.SearchYouTubeVideoID(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.LookupSongs(ctx, videoID)
+}
+
+// parseYoutubeURL indica si la entrada es una URL de YouTube y devuelve el ID del video, si lo indica, y sus parámetros.
+func parseYoutubeURL(input string) (videoID string, query url.Values, ok bool) {
+	u, err := url.Parse(strings.TrimSpace(input))
+	if err != nil {
+		return "", nil, false
+	}
+	query = u.Query()
+	switch strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.") {
+	case "youtube.com", "m.youtube.com", "music.youtube.com":
+		return query.Get("v"), query, true
+	case "youtu.be":
+		return strings.Trim(u.Path, "/"), query, true
+	default:
+		return "", nil, false
+	}
+}
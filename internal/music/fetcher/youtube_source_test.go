@@ -0,0 +1,52 @@
+package fetcher
+
+import (
+	"context"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestYoutubeFetcher_CanHandle(t *testing.T) {
+	fetcher := &YoutubeFetcher{}
+
+	assert.True(t, fetcher.CanHandle("https://www.youtube.com/watch?v=abc"))
+	assert.True(t, fetcher.CanHandle("https://youtu.be/abc"))
+	assert.False(t, fetcher.CanHandle("https://radio.example.com/stream"))
+	assert.False(t, fetcher.CanHandle("la bersuit"))
+}
+
+func TestYoutubeFetcher_Resolve(t *testing.T) {
+	t.Run("VideoURL", func(t *testing.T) {
+		youtubeService := new(MockYouTubeService)
+		cacheManager := new(MockCacheManager)
+		logger := new(logging.MockLogger)
+		logger.On("Info", mock.Anything, mock.Anything).Return()
+		fetcher := &YoutubeFetcher{Logger: logger, Cache: cacheManager, YoutubeService: youtubeService}
+
+		cacheManager.On("Get", youtubeWatchURLPrefix+"abc").Return([]*voice.Song{{Title: "Tema"}})
+
+		songs, err := fetcher.Resolve(context.Background(), "https://youtu.be/abc?t=42")
+		assert.NoError(t, err)
+		assert.Equal(t, "Tema", songs[0].Title)
+		youtubeService.AssertNotCalled(t, "SearchVideoID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("SearchTerm", func(t *testing.T) {
+		youtubeService := new(MockYouTubeService)
+		cacheManager := new(MockCacheManager)
+		logger := new(logging.MockLogger)
+		logger.On("Info", mock.Anything, mock.Anything).Return()
+		fetcher := &YoutubeFetcher{Logger: logger, Cache: cacheManager, YoutubeService: youtubeService}
+
+		youtubeService.On("SearchVideoID", mock.Anything, "la bersuit").Return("xyz", nil)
+		cacheManager.On("Get", youtubeWatchURLPrefix+"xyz").Return([]*voice.Song{{Title: "Se viene"}})
+
+		songs, err := fetcher.Resolve(context.Background(), "la bersuit")
+		assert.NoError(t, err)
+		assert.Equal(t, "Se viene", songs[0].Title)
+		youtubeService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,50 @@
+package httpaudio
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress indica que la URL apunta a una dirección de la red local del bot.
+var ErrPrivateAddress = errors.New("la URL apunta a una dirección privada")
+
+// NewPublicClient crea un cliente HTTP que solo se conecta a direcciones públicas. Como cualquier usuario
+// puede pedir una URL, así se evita que el bot consulte servicios de su propia red. La dirección se revisa
+// al conectarse, después de resolver el nombre, así que también cubre las redirecciones y los nombres que
+// resuelven a direcciones privadas.
+func NewPublicClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			return checkPublicAddress(address)
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Con un proxy la conexión sería al proxy y no se podría revisar la dirección de destino.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Transport: transport}
+}
+
+// checkPublicAddress devuelve ErrPrivateAddress si la dirección "ip:puerto" es privada, de loopback,
+// de enlace local o no especificada.
+func checkPublicAddress(address string) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("dirección inválida %q: %w", address, err)
+	}
+
+	addr := addrPort.Addr().Unmap()
+	if addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsUnspecified() {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, addr)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package httpaudio
+
+import (
+	"context"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckPublicAddress(t *testing.T) {
+	for _, address := range []string{
+		"127.0.0.1:80", "[::1]:443", "10.0.0.5:8080", "172.16.3.4:80", "192.168.1.1:80",
+		"169.254.169.254:80", "[fe80::1]:80", "0.0.0.0:80", "[::ffff:127.0.0.1]:80", "[fd00::1]:80",
+	} {
+		assert.ErrorIs(t, checkPublicAddress(address), ErrPrivateAddress, address)
+	}
+
+	assert.NoError(t, checkPublicAddress("190.12.34.56:443"))
+	assert.NoError(t, checkPublicAddress("[2800:3f0:4002:80a::200e]:443"))
+	assert.Error(t, checkPublicAddress("radio.example.com:80"))
+}
+
+func TestNewPublicClient_BlocksLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Write([]byte("mp3!"))
+	}))
+	defer server.Close()
+
+	logger := new(logging.MockLogger)
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+	source := NewSource(NewPublicClient(), logger)
+
+	_, err := source.Resolve(context.Background(), server.URL+"/tema.mp3")
+
+	assert.ErrorIs(t, err, ErrPrivateAddress)
+}
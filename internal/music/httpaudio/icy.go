@@ -0,0 +1,87 @@
+package httpaudio
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+// icyReader quita los bloques de metadatos que intercalan las radios Icecast y Shoutcast en el audio
+// cada metaInt bytes, y guarda el último título que anunciaron.
+type icyReader struct {
+	r         io.Reader
+	metaInt   int
+	remaining int // Bytes de audio que faltan hasta el próximo bloque de metadatos.
+
+	mu    sync.Mutex
+	title string
+}
+
+// newIcyReader crea un lector para un flujo con un bloque de metadatos cada metaInt bytes de audio.
+func newIcyReader(r io.Reader, metaInt int) *icyReader {
+	return &icyReader{r: r, metaInt: metaInt, remaining: metaInt}
+}
+
+// Read lee solo el audio del flujo. Al llegar a un bloque de metadatos lo lee completo y actualiza el título.
+func (r *icyReader) Read(p []byte) (int, error) {
+	if r.remaining == 0 {
+		if err := r.readMetadata(); err != nil {
+			return 0, err
+		}
+		r.remaining = r.metaInt
+	}
+
+	n, err := r.r.Read(p[:min(len(p), r.remaining)])
+	r.remaining -= n
+	return n, err
+}
+
+// StreamTitle devuelve el último título que anunció la radio.
+func (r *icyReader) StreamTitle() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.title
+}
+
+// readMetadata lee un bloque de metadatos. El primer byte indica el largo del bloque en múltiplos de 16 bytes;
+// un largo cero significa que los metadatos no cambiaron.
+func (r *icyReader) readMetadata() error {
+	var length [1]byte
+	if _, err := io.ReadFull(r.r, length[:]); err != nil {
+		return err
+	}
+	if length[0] == 0 {
+		return nil
+	}
+
+	metadata := make([]byte, int(length[0])*16)
+	if _, err := io.ReadFull(r.r, metadata); err != nil {
+		return err
+	}
+	if title, ok := parseStreamTitle(string(metadata)); ok {
+		r.mu.Lock()
+		r.title = title
+		r.mu.Unlock()
+	}
+	return nil
+}
+
+// parseStreamTitle obtiene el valor de StreamTitle de un bloque de metadatos con el formato StreamTitle='...';
+func parseStreamTitle(metadata string) (string, bool) {
+	const key = "StreamTitle='"
+	start := strings.Index(metadata, key)
+	if start < 0 {
+		return "", false
+	}
+	value := strings.TrimRight(metadata[start+len(key):], "\x00")
+	// El título puede tener comillas simples, así que termina donde empieza el campo siguiente
+	// o, si no hay otro campo, en la última comilla seguida de punto y coma.
+	end := strings.Index(value, "';Stream")
+	if end < 0 {
+		end = strings.LastIndex(value, "';")
+	}
+	if end < 0 {
+		return "", false
+	}
+	return strings.TrimSpace(value[:end]), true
+}
@@ -0,0 +1,65 @@
+package httpaudio
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+// icyBlock arma un bloque de metadatos ICY con su byte de largo y el relleno hasta un múltiplo de 16.
+func icyBlock(metadata string) []byte {
+	blocks := (len(metadata) + 15) / 16
+	block := make([]byte, 1+blocks*16)
+	block[0] = byte(blocks)
+	copy(block[1:], metadata)
+	return block
+}
+
+func TestIcyReader_Read(t *testing.T) {
+	var stream bytes.Buffer
+	stream.WriteString("abcd")
+	stream.Write(icyBlock("StreamTitle='Soda Stereo - De música ligera';"))
+	stream.WriteString("efgh")
+	stream.Write(icyBlock(""))
+	stream.WriteString("ij")
+
+	reader := newIcyReader(&stream, 4)
+	audio, err := io.ReadAll(reader)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "abcdefghij", string(audio))
+	assert.Equal(t, "Soda Stereo - De música ligera", reader.StreamTitle())
+}
+
+func TestIcyReader_TruncatedMetadata(t *testing.T) {
+	reader := newIcyReader(strings.NewReader("abcd\x02Stream"), 4)
+
+	_, err := io.ReadAll(reader)
+
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestParseStreamTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		title    string
+		ok       bool
+	}{
+		{name: "Title", metadata: "StreamTitle='Charly García - Demoliendo hoteles';", title: "Charly García - Demoliendo hoteles", ok: true},
+		{name: "WithURL", metadata: "StreamTitle='Los Redondos - Ji ji ji';StreamUrl='https://radio.example.com';", title: "Los Redondos - Ji ji ji", ok: true},
+		{name: "Quote", metadata: "StreamTitle='Rock 'n' roll';\x00\x00", title: "Rock 'n' roll", ok: true},
+		{name: "Empty", metadata: "StreamTitle='';", title: "", ok: true},
+		{name: "Missing", metadata: "StreamUrl='https://radio.example.com';", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, ok := parseStreamTitle(tt.metadata)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.title, title)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package httpaudio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/encoder"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"go.uber.org/zap"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SongType es el tipo de las canciones que se reproducen directamente desde una URL de audio.
+const SongType = "http_audio"
+
+var (
+	// ErrNotAudio indica que la URL no devuelve audio que se pueda reproducir.
+	ErrNotAudio = errors.New("la URL no es de audio")
+	// ErrAttachmentExpired indica que el enlace firmado de un archivo adjunto de Discord venció o el archivo se borró.
+	ErrAttachmentExpired = errors.New("el enlace del archivo adjunto venció o el archivo se borró, volvé a pedirlo")
+)
+
+// discordCDNHosts son los servidores de Discord que sirven los archivos adjuntos con enlaces firmados que vencen.
+var discordCDNHosts = map[string]struct{}{
+	"cdn.discordapp.com": {}, "media.discordapp.net": {},
+}
+
+// audioExtensions son las extensiones de archivo que se aceptan cuando el servidor no informa un tipo de audio.
+var audioExtensions = map[string]struct{}{
+	".mp3": {}, ".ogg": {}, ".oga": {}, ".opus": {}, ".aac": {}, ".m4a": {}, ".flac": {}, ".wav": {},
+}
+
+// liveMediaTypes son los tipos de audio que solo envían las radios.
+var liveMediaTypes = map[string]struct{}{
+	"audio/aacp": {}, "audio/x-aacp": {},
+}
+
+// Source reproduce archivos de audio (MP3, OGG, AAC...) y radios Icecast o Shoutcast desde su URL.
+// Las radios se tratan como transmisiones en vivo: no tienen duración, no se pueden reposicionar y
+// su audio nunca se guarda en caché.
+type Source struct {
+	client *http.Client
+	logger logging.Logger
+}
+
+// NewSource crea una fuente de audio por HTTP que hace los pedidos con client.
+func NewSource(client *http.Client, logger logging.Logger) *Source {
+	return &Source{client: client, logger: logger}
+}
+
+// Name devuelve el tipo de las canciones que produce la fuente.
+func (s *Source) Name() string {
+	return SongType
+}
+
+// CanHandle indica si la entrada es una URL HTTP o HTTPS. Las URLs de otras fuentes, como YouTube,
+// deben registrarse antes para que las resuelva su fuente.
+func (s *Source) CanHandle(input string) bool {
+	u, err := url.Parse(strings.TrimSpace(input))
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// Resolve consulta la URL para comprobar que devuelve audio y arma la canción. Las radios usan el nombre
+// que anuncian como título; los archivos, el nombre del archivo.
+func (s *Source) Resolve(ctx context.Context, input string) ([]*voice.Song, error) {
+	input = strings.TrimSpace(input)
+	resp, err := s.get(ctx, input, false)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	if !isAudio(resp, input) {
+		return nil, fmt.Errorf("%w: %s", ErrNotAudio, resp.Header.Get("Content-Type"))
+	}
+
+	song := &voice.Song{
+		Type:     SongType,
+		Title:    titleFromURL(input),
+		URL:      input,
+		Playable: true,
+		Live:     isLive(resp),
+	}
+	if name := strings.TrimSpace(resp.Header.Get("Icy-Name")); name != "" {
+		song.Title = name
+	}
+	s.logger.Info("Audio por HTTP encontrado", zap.String("URL", input), zap.Bool("en vivo", song.Live))
+	return []*voice.Song{song}, nil
+}
+
+// GetDCAData descarga el audio de la canción y lo codifica a DCA mientras se reproduce. En las radios se piden
+// los metadatos ICY, y los datos devueltos implementan voice.StreamTitler con el título que anuncia la radio.
+func (s *Source) GetDCAData(ctx context.Context, song *voice.Song) (io.Reader, error) {
+	// Los archivos adjuntos de Discord quedan en la lista con su enlace firmado, que vence a las pocas horas.
+	if attachmentExpired(song.URL, time.Now()) {
+		return nil, ErrAttachmentExpired
+	}
+
+	resp, err := s.get(ctx, song.URL, song.Live)
+	if err != nil {
+		return nil, err
+	}
+
+	var audio io.Reader = resp.Body
+	var icy *icyReader
+	if metaInt, err := strconv.Atoi(resp.Header.Get("Icy-Metaint")); err == nil && metaInt > 0 {
+		icy = newIcyReader(resp.Body, metaInt)
+		audio = icy
+	}
+
+	options := *encoder.StdEncodeOptions
+	// El reproductor lee los cuadros de opus sin el encabezado de metadatos de DCA.
+	options.RawOutput = true
+	options.Volume = song.GetVolume()
+	if !song.Live {
+		options.StartTime = int(song.StartPosition.Seconds())
+	}
+
+	session, err := encoder.EncodeMem(audio, &options, ctx, s.logger)
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("error al codificar el audio: %w", err)
+	}
+	// Cerrar la respuesta corta la descarga de las radios, que nunca terminan, al saltar o detener la canción.
+	context.AfterFunc(ctx, func() { resp.Body.Close() })
+
+	if icy != nil {
+		return &liveStream{Reader: session, icy: icy}, nil
+	}
+	return session, nil
+}
+
+// get hace el pedido de la URL y comprueba que la respuesta sea exitosa. Con icyMetadata se piden
+// los metadatos intercalados de las radios.
+func (s *Source) get(ctx context.Context, url string, icyMetadata bool) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error al armar el pedido: %w", err)
+	}
+	if icyMetadata {
+		req.Header.Set("Icy-MetaData", "1")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.logger.Error("Error al pedir el audio por HTTP", zap.String("URL", url), zap.Error(err))
+		return nil, fmt.Errorf("error al pedir el audio: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		if isDiscordCDN(url) && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+			return nil, ErrAttachmentExpired
+		}
+		return nil, fmt.Errorf("el servidor respondió %s", resp.Status)
+	}
+	return resp, nil
+}
+
+// liveStream son los datos de audio de una radio, con el título que anuncia.
+type liveStream struct {
+	io.Reader
+	icy *icyReader
+}
+
+// StreamTitle devuelve el último título que anunció la radio.
+func (l *liveStream) StreamTitle() string {
+	return l.icy.StreamTitle()
+}
+
+// isAudio indica si la respuesta es de audio, según su tipo o, si el servidor no lo informa, según la extensión.
+func isAudio(resp *http.Response, rawURL string) bool {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch {
+	case strings.HasPrefix(mediaType, "audio/"), mediaType == "application/ogg":
+		// Las listas de reproducción M3U y PLS también se informan como audio, pero no son audio.
+		return !strings.Contains(mediaType, "mpegurl") && mediaType != "audio/x-scpls"
+	case mediaType == "" || mediaType == "application/octet-stream":
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return false
+		}
+		_, ok := audioExtensions[strings.ToLower(path.Ext(u.Path))]
+		return ok
+	default:
+		return false
+	}
+}
+
+// isLive indica si la respuesta es una transmisión en vivo: las radios Shoutcast e Icecast envían encabezados
+// icy-* o ice-*, y algunos tipos de audio solo se usan en radios. Que falte el largo no alcanza, porque muchos
+// servidores envían los archivos comunes por partes sin informarlo.
+func isLive(resp *http.Response) bool {
+	for key := range resp.Header {
+		key = strings.ToLower(key)
+		if strings.HasPrefix(key, "icy-") || strings.HasPrefix(key, "ice-") {
+			return true
+		}
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	_, ok := liveMediaTypes[mediaType]
+	return ok
+}
+
+// isDiscordCDN indica si la URL es de un archivo adjunto de Discord.
+func isDiscordCDN(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	_, ok := discordCDNHosts[strings.ToLower(u.Hostname())]
+	return ok
+}
+
+// attachmentExpired indica si la URL es de un archivo adjunto de Discord cuyo enlace ya venció en now. Discord
+// informa el vencimiento en el parámetro ex, en segundos desde la época Unix escritos en hexadecimal.
+func attachmentExpired(rawURL string, now time.Time) bool {
+	if !isDiscordCDN(rawURL) {
+		return false
+	}
+	u, _ := url.Parse(rawURL)
+	expiresAt, err := strconv.ParseInt(u.Query().Get("ex"), 16, 64)
+	if err != nil {
+		return false
+	}
+	return !now.Before(time.Unix(expiresAt, 0))
+}
+
+// titleFromURL arma un título con el nombre del archivo de la URL, o con el servidor si la URL no tiene archivo.
+func titleFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	name := path.Base(u.Path)
+	if name == "/" || name == "." {
+		return u.Host
+	}
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	if title := strings.TrimSuffix(name, path.Ext(name)); title != "" {
+		return title
+	}
+	return name
+}
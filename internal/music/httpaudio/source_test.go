@@ -0,0 +1,141 @@
+package httpaudio
+
+import (
+	"context"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSource_CanHandle(t *testing.T) {
+	source := NewSource(http.DefaultClient, new(logging.MockLogger))
+
+	assert.True(t, source.CanHandle("https://radio.example.com/stream"))
+	assert.True(t, source.CanHandle("http://example.com/tema.mp3"))
+	assert.False(t, source.CanHandle("ftp://example.com/tema.mp3"))
+	assert.False(t, source.CanHandle("la bersuit"))
+}
+
+func TestSource_Resolve(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/musica/Flaca - Calamaro.mp3":
+			w.Header().Set("Content-Type", "audio/mpeg")
+			w.Header().Set("Content-Length", "4")
+			w.Write([]byte("mp3!"))
+		case "/radio":
+			w.Header().Set("Content-Type", "audio/aac")
+			w.Header().Set("Icy-Name", "Radio Nacional Rock")
+			w.Header().Set("Icy-Metaint", "16000")
+			w.(http.Flusher).Flush()
+		case "/por-partes.mp3":
+			// Sin Content-Length, como los servidores que envían los archivos por partes.
+			w.Header().Set("Content-Type", "audio/mpeg")
+			w.(http.Flusher).Flush()
+			w.Write([]byte("mp3!"))
+		case "/aacplus":
+			w.Header().Set("Content-Type", "audio/aacp")
+			w.(http.Flusher).Flush()
+		case "/sin-tipo.ogg":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("Content-Length", "3")
+			w.Write([]byte("ogg"))
+		case "/pagina":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html></html>"))
+		case "/lista.m3u":
+			w.Header().Set("Content-Type", "audio/x-mpegurl")
+			w.Write([]byte("#EXTM3U"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	logger := new(logging.MockLogger)
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+	source := NewSource(server.Client(), logger)
+
+	t.Run("File", func(t *testing.T) {
+		songs, err := source.Resolve(context.Background(), server.URL+"/musica/Flaca%20-%20Calamaro.mp3")
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, SongType, songs[0].Type)
+		assert.Equal(t, "Flaca - Calamaro", songs[0].Title)
+		assert.True(t, songs[0].Playable)
+		assert.False(t, songs[0].Live)
+	})
+
+	t.Run("Radio", func(t *testing.T) {
+		songs, err := source.Resolve(context.Background(), server.URL+"/radio")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Radio Nacional Rock", songs[0].Title)
+		assert.True(t, songs[0].Live)
+	})
+
+	t.Run("ChunkedFileIsNotLive", func(t *testing.T) {
+		songs, err := source.Resolve(context.Background(), server.URL+"/por-partes.mp3")
+
+		assert.NoError(t, err)
+		assert.False(t, songs[0].Live)
+	})
+
+	t.Run("LiveContentType", func(t *testing.T) {
+		songs, err := source.Resolve(context.Background(), server.URL+"/aacplus")
+
+		assert.NoError(t, err)
+		assert.True(t, songs[0].Live)
+	})
+
+	t.Run("OctetStreamWithExtension", func(t *testing.T) {
+		songs, err := source.Resolve(context.Background(), server.URL+"/sin-tipo.ogg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "sin-tipo", songs[0].Title)
+	})
+
+	t.Run("NotAudio", func(t *testing.T) {
+		_, err := source.Resolve(context.Background(), server.URL+"/pagina")
+
+		assert.ErrorIs(t, err, ErrNotAudio)
+	})
+
+	t.Run("Playlist", func(t *testing.T) {
+		_, err := source.Resolve(context.Background(), server.URL+"/lista.m3u")
+
+		assert.ErrorIs(t, err, ErrNotAudio)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := source.Resolve(context.Background(), server.URL+"/nada.mp3")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestAttachmentExpired(t *testing.T) {
+	now := time.Unix(0x68000000, 0)
+
+	assert.True(t, attachmentExpired("https://cdn.discordapp.com/attachments/1/2/tema.mp3?ex=67ffffff&is=67fe0000&hm=abc", now))
+	assert.True(t, attachmentExpired("https://media.discordapp.net/attachments/1/2/tema.mp3?ex=68000000", now))
+	assert.False(t, attachmentExpired("https://cdn.discordapp.com/attachments/1/2/tema.mp3?ex=68000001", now))
+	assert.False(t, attachmentExpired("https://cdn.discordapp.com/attachments/1/2/tema.mp3", now))
+	assert.False(t, attachmentExpired("https://radio.example.com/stream?ex=1", now))
+}
+
+func TestSource_GetDCAData_ExpiredAttachment(t *testing.T) {
+	source := NewSource(http.DefaultClient, new(logging.MockLogger))
+	song := &voice.Song{Type: SongType, Title: "tema.mp3", URL: "https://cdn.discordapp.com/attachments/1/2/tema.mp3?ex=65000000&is=64fe0000&hm=abc"}
+
+	_, err := source.GetDCAData(context.Background(), song)
+
+	assert.ErrorIs(t, err, ErrAttachmentExpired)
+}
@@ -0,0 +1,252 @@
+package locallibrary
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/Tomas-vilte/GoMusicBot/internal/encoder"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"go.uber.org/zap"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SongType es el tipo de las canciones de la biblioteca local.
+	SongType = "local_library"
+	// Prefix es el prefijo con el que se buscan canciones en la biblioteca, por ejemplo "local:soda stereo".
+	Prefix = "local:"
+)
+
+var (
+	// ErrNoAudio indica que el archivo no tiene una pista de audio.
+	ErrNoAudio = errors.New("el archivo no tiene audio")
+	// ErrNoMatch indica que ninguna canción de la biblioteca coincide con la búsqueda.
+	ErrNoMatch = errors.New("no se encontró ninguna canción en la biblioteca")
+	// ErrNotIndexed indica que la canción no está en la biblioteca, por ejemplo porque se borró el archivo.
+	ErrNotIndexed = errors.New("la canción no está en la biblioteca")
+)
+
+// audioExtensions son las extensiones de los archivos que se indexan.
+var audioExtensions = map[string]struct{}{
+	".mp3": {}, ".ogg": {}, ".oga": {}, ".opus": {}, ".m4a": {}, ".aac": {}, ".flac": {}, ".wav": {},
+}
+
+type (
+	// CommandExecutor ejecuta los comandos externos, ffprobe y ffmpeg, que usa la biblioteca.
+	CommandExecutor interface {
+		ExecuteCommand(ctx context.Context, name string, args ...string) *exec.Cmd
+	}
+
+	// Track es un archivo de audio de la biblioteca.
+	Track struct {
+		Path      string // Ruta del archivo relativa al directorio de la biblioteca, con barras "/".
+		Title     string
+		Artist    string
+		Album     string
+		Duration  time.Duration
+		HasCover  bool   // Indica que el archivo tiene una portada incrustada.
+		CoverFile string // Ruta de la portada extraída, vacía si no se pudo extraer.
+	}
+)
+
+// Library es una biblioteca de archivos de audio en un directorio local. Indexa las etiquetas, la duración y
+// la portada de cada archivo con ffprobe, y los reproduce codificándolos con el encoder.
+type Library struct {
+	dir      string
+	coverDir string
+	executor CommandExecutor
+	logger   logging.Logger
+
+	mu     sync.RWMutex
+	tracks map[string]*Track
+}
+
+// NewLibrary crea una biblioteca para los archivos de dir. Las portadas se extraen en coverDir; si está vacío,
+// las canciones se muestran sin portada.
+func NewLibrary(dir, coverDir string, executor CommandExecutor, logger logging.Logger) *Library {
+	return &Library{
+		dir:      dir,
+		coverDir: coverDir,
+		executor: executor,
+		logger:   logger,
+		tracks:   make(map[string]*Track),
+	}
+}
+
+// Index recorre el directorio de la biblioteca y reemplaza el índice por los archivos de audio que encuentra.
+// Los archivos que ffprobe no puede leer se omiten.
+func (l *Library) Index(ctx context.Context) error {
+	tracks := make(map[string]*Track)
+	err := filepath.WalkDir(l.dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		if _, ok := audioExtensions[strings.ToLower(filepath.Ext(filePath))]; !ok {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(l.dir, filePath)
+		if err != nil {
+			return err
+		}
+		track, err := l.probe(ctx, filePath, filepath.ToSlash(relPath))
+		if err != nil {
+			l.logger.Warn("No se pudo indexar el archivo", zap.String("archivo", filePath), zap.Error(err))
+			return nil
+		}
+		tracks[track.Path] = track
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error al indexar la biblioteca: %w", err)
+	}
+
+	l.mu.Lock()
+	l.tracks = tracks
+	l.mu.Unlock()
+	l.logger.Info("Biblioteca local indexada", zap.String("directorio", l.dir), zap.Int("canciones", len(tracks)))
+	return nil
+}
+
+// Search devuelve como mucho limit pistas cuyo título, artista, álbum o nombre de archivo se parecen a la búsqueda,
+// de la más parecida a la menos parecida. Tolera palabras incompletas, acentos y errores de tipeo.
+func (l *Library) Search(query string, limit int) []*Track {
+	l.mu.RLock()
+	tracks := make([]*Track, 0, len(l.tracks))
+	for _, track := range l.tracks {
+		tracks = append(tracks, track)
+	}
+	l.mu.RUnlock()
+	// Se ordenan para que el resultado no dependa del orden del mapa.
+	sort.Slice(tracks, func(i, j int) bool { return tracks[i].Path < tracks[j].Path })
+
+	return search(tracks, query, limit)
+}
+
+// Name devuelve el tipo de las canciones que produce la biblioteca.
+func (l *Library) Name() string {
+	return SongType
+}
+
+// CanHandle indica si la entrada empieza con el prefijo de la biblioteca.
+func (l *Library) CanHandle(input string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(input)), Prefix)
+}
+
+// Resolve devuelve la canción de la biblioteca que mejor coincide con la búsqueda que sigue al prefijo. Si la búsqueda
+// es la ruta de un archivo de la biblioteca, como las URLs de las canciones, devuelve ese archivo.
+func (l *Library) Resolve(_ context.Context, input string) ([]*voice.Song, error) {
+	query := strings.TrimSpace(strings.TrimSpace(input)[len(Prefix):])
+	if track := l.track(query); track != nil {
+		return []*voice.Song{track.song()}, nil
+	}
+
+	matches := l.Search(query, 1)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoMatch, query)
+	}
+	l.logger.Info("Canción encontrada en la biblioteca local", zap.String("búsqueda", query), zap.String("archivo", matches[0].Path))
+	return []*voice.Song{matches[0].song()}, nil
+}
+
+// GetDCAData codifica el archivo de la canción a DCA mientras se reproduce, desde la posición de inicio de la canción.
+func (l *Library) GetDCAData(ctx context.Context, song *voice.Song) (io.Reader, error) {
+	relPath := strings.TrimPrefix(song.URL, Prefix)
+	// Solo se reproducen archivos indexados, así una URL armada a mano no puede salir del directorio.
+	if l.track(relPath) == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotIndexed, relPath)
+	}
+
+	options := *encoder.StdEncodeOptions
+	// El reproductor lee los cuadros de opus sin el encabezado de metadatos de DCA.
+	options.RawOutput = true
+	options.Volume = song.GetVolume()
+	options.StartTime = int(song.StartPosition.Seconds())
+
+	session, err := encoder.EncodeFile(filepath.Join(l.dir, filepath.FromSlash(relPath)), &options, ctx, l.logger)
+	if err != nil {
+		return nil, fmt.Errorf("error al codificar el archivo: %w", err)
+	}
+	return session, nil
+}
+
+// track devuelve la pista indexada con la ruta relativa, o nil si no está en la biblioteca.
+func (l *Library) track(relPath string) *Track {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.tracks[relPath]
+}
+
+// probe lee las etiquetas, la duración y la portada del archivo con ffprobe y, si tiene portada, la extrae.
+func (l *Library) probe(ctx context.Context, filePath, relPath string) (*Track, error) {
+	output, err := l.executor.ExecuteCommand(ctx, "ffprobe", "-v", "quiet", "-print_format", "json", "-show_format", "-show_streams", filePath).Output()
+	if err != nil {
+		return nil, fmt.Errorf("error al ejecutar ffprobe: %w", err)
+	}
+	track, err := parseProbe(relPath, output)
+	if err != nil {
+		return nil, err
+	}
+
+	if track.HasCover && l.coverDir != "" {
+		coverFile, err := l.extractCover(ctx, filePath, relPath)
+		if err != nil {
+			l.logger.Warn("No se pudo extraer la portada", zap.String("archivo", filePath), zap.Error(err))
+		} else {
+			track.CoverFile = coverFile
+		}
+	}
+	return track, nil
+}
+
+// extractCover guarda la portada incrustada del archivo como JPEG en el directorio de portadas. Las portadas
+// ya extraídas en una indexación anterior se reutilizan.
+func (l *Library) extractCover(ctx context.Context, filePath, relPath string) (string, error) {
+	sum := sha1.Sum([]byte(relPath))
+	coverFile := filepath.Join(l.coverDir, hex.EncodeToString(sum[:])+".jpg")
+	if _, err := os.Stat(coverFile); err == nil {
+		return coverFile, nil
+	}
+	if err := os.MkdirAll(l.coverDir, 0o755); err != nil {
+		return "", err
+	}
+
+	if err := l.executor.ExecuteCommand(ctx, "ffmpeg", "-v", "quiet", "-y", "-i", filePath, "-an", "-frames:v", "1", coverFile).Run(); err != nil {
+		return "", fmt.Errorf("error al ejecutar ffmpeg: %w", err)
+	}
+	return coverFile, nil
+}
+
+// song arma la canción de la pista. El título incluye al artista, si se conoce.
+func (t *Track) song() *voice.Song {
+	title := t.Title
+	if t.Artist != "" {
+		title = fmt.Sprintf("%s - %s", t.Artist, t.Title)
+	}
+	return &voice.Song{
+		Type:          SongType,
+		Title:         title,
+		URL:           Prefix + t.Path,
+		Channel:       t.Artist,
+		Playable:      true,
+		Duration:      t.Duration,
+		ThumbnailFile: t.CoverFile,
+	}
+}
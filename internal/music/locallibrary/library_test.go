@@ -0,0 +1,109 @@
+package locallibrary
+
+import (
+	"context"
+	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeExecutor responde a ffprobe con la salida configurada para cada archivo y simula ffmpeg creando el archivo de salida.
+type fakeExecutor struct {
+	probes map[string]string
+}
+
+func (e *fakeExecutor) ExecuteCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
+	last := args[len(args)-1]
+	switch name {
+	case "ffprobe":
+		output, ok := e.probes[filepath.Base(last)]
+		if !ok {
+			return exec.CommandContext(ctx, "false")
+		}
+		return exec.CommandContext(ctx, "printf", "%s", output)
+	default:
+		return exec.CommandContext(ctx, "touch", last)
+	}
+}
+
+// newTestLibrary crea una biblioteca con los archivos indicados en un directorio temporal.
+func newTestLibrary(t *testing.T, coverDir string, files ...string) *Library {
+	dir := t.TempDir()
+	for _, file := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, nil, 0o644))
+	}
+
+	logger := new(logging.MockLogger)
+	logger.On("Info", mock.Anything, mock.Anything).Return()
+	logger.On("Warn", mock.Anything, mock.Anything).Return()
+	executor := &fakeExecutor{probes: map[string]string{
+		"flaca.mp3":    `{"streams":[{"codec_type":"audio"},{"codec_type":"video","disposition":{"attached_pic":1}}],"format":{"duration":"275.5","tags":{"title":"Flaca","artist":"Andrés Calamaro","album":"Alta suciedad"}}}`,
+		"mariposa.ogg": `{"streams":[{"codec_type":"audio","tags":{"TITLE":"Mariposa Tecknicolor","ARTIST":"Fito Páez"}}],"format":{"duration":"290"}}`,
+		"roto.mp3":     `{"streams":[],"format":{}}`,
+	}}
+	return NewLibrary(dir, coverDir, executor, logger)
+}
+
+func TestLibrary_Index(t *testing.T) {
+	coverDir := t.TempDir()
+	library := newTestLibrary(t, coverDir, "rock/flaca.mp3", "rock/mariposa.ogg", "rock/roto.mp3", "notas.txt")
+
+	err := library.Index(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, library.tracks, 2)
+	flaca := library.track("rock/flaca.mp3")
+	if assert.NotNil(t, flaca) {
+		assert.Equal(t, "Flaca", flaca.Title)
+		assert.Equal(t, "Andrés Calamaro", flaca.Artist)
+		assert.Equal(t, 275500*time.Millisecond, flaca.Duration)
+		assert.FileExists(t, flaca.CoverFile)
+	}
+	assert.Equal(t, "Fito Páez", library.track("rock/mariposa.ogg").Artist)
+}
+
+func TestLibrary_Resolve(t *testing.T) {
+	library := newTestLibrary(t, "", "rock/flaca.mp3", "rock/mariposa.ogg")
+	assert.NoError(t, library.Index(context.Background()))
+
+	t.Run("Fuzzy", func(t *testing.T) {
+		songs, err := library.Resolve(context.Background(), "local: calamaro flca")
+
+		assert.NoError(t, err)
+		assert.Len(t, songs, 1)
+		assert.Equal(t, SongType, songs[0].Type)
+		assert.Equal(t, "Andrés Calamaro - Flaca", songs[0].Title)
+		assert.Equal(t, "local:rock/flaca.mp3", songs[0].URL)
+		assert.Empty(t, songs[0].ThumbnailFile)
+	})
+
+	t.Run("Path", func(t *testing.T) {
+		songs, err := library.Resolve(context.Background(), "local:rock/mariposa.ogg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Fito Páez - Mariposa Tecknicolor", songs[0].Title)
+		assert.Equal(t, 290*time.Second, songs[0].Duration)
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		_, err := library.Resolve(context.Background(), "local:charly garcía")
+
+		assert.ErrorIs(t, err, ErrNoMatch)
+	})
+}
+
+func TestLibrary_CanHandle(t *testing.T) {
+	library := NewLibrary("", "", nil, nil)
+
+	assert.True(t, library.CanHandle("local:flaca"))
+	assert.True(t, library.CanHandle(" Local:flaca"))
+	assert.False(t, library.CanHandle("flaca"))
+	assert.False(t, library.CanHandle("https://example.com/local:flaca.mp3"))
+}
@@ -0,0 +1,126 @@
+package locallibrary
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// accentReplacer quita los acentos más comunes para que "cancion" coincida con "Canción".
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "ä", "a", "â", "a", "ã", "a",
+	"é", "e", "è", "e", "ë", "e", "ê", "e",
+	"í", "i", "ì", "i", "ï", "i", "î", "i",
+	"ó", "o", "ò", "o", "ö", "o", "ô", "o", "õ", "o",
+	"ú", "u", "ù", "u", "ü", "u", "û", "u",
+	"ñ", "n", "ç", "c",
+)
+
+// normalizeWords pasa el texto a minúsculas sin acentos y lo separa en palabras, ignorando la puntuación.
+func normalizeWords(s string) []string {
+	s = accentReplacer.Replace(strings.ToLower(s))
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// trackWords devuelve las palabras por las que se puede encontrar la pista: título, artista, álbum y nombre del archivo.
+func trackWords(track *Track) []string {
+	name := path.Base(track.Path)
+	return normalizeWords(strings.Join([]string{track.Title, track.Artist, track.Album, strings.TrimSuffix(name, path.Ext(name))}, " "))
+}
+
+// wordScore puntúa qué tan bien coincide una palabra de la búsqueda con las palabras de la pista: 3 si es igual,
+// 2 si es el comienzo de una palabra, 1 si está contenida o tiene algún error de tipeo y 0 si no coincide.
+func wordScore(query string, words []string) int {
+	best := 0
+	for _, word := range words {
+		score := 0
+		switch {
+		case word == query:
+			score = 3
+		case strings.HasPrefix(word, query):
+			score = 2
+		case len(query) >= 3 && strings.Contains(word, query):
+			score = 1
+		case levenshtein(query, word) <= len([]rune(query))/4:
+			// Se tolera un error cada cuatro letras, así que las palabras cortas tienen que ser exactas.
+			score = 1
+		}
+		best = max(best, score)
+	}
+	return best
+}
+
+// matchScore puntúa la pista para la búsqueda. Todas las palabras de la búsqueda tienen que coincidir; si alguna
+// no coincide devuelve 0.
+func matchScore(queryWords []string, track *Track) int {
+	words := trackWords(track)
+	total := 0
+	for _, query := range queryWords {
+		score := wordScore(query, words)
+		if score == 0 {
+			return 0
+		}
+		total += score
+	}
+	return total
+}
+
+// search devuelve como mucho limit pistas que coinciden con la búsqueda, de la más parecida a la menos parecida.
+func search(tracks []*Track, query string, limit int) []*Track {
+	queryWords := normalizeWords(query)
+	if len(queryWords) == 0 {
+		return nil
+	}
+
+	type scoredTrack struct {
+		track *Track
+		score int
+	}
+	var matches []scoredTrack
+	for _, track := range tracks {
+		if score := matchScore(queryWords, track); score > 0 {
+			matches = append(matches, scoredTrack{track: track, score: score})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		// Entre pistas igual de parecidas gana la de título más corto, que tiene menos palabras de sobra.
+		if len(matches[i].track.Title) != len(matches[j].track.Title) {
+			return len(matches[i].track.Title) < len(matches[j].track.Title)
+		}
+		return matches[i].track.Path < matches[j].track.Path
+	})
+
+	results := make([]*Track, 0, min(limit, len(matches)))
+	for _, match := range matches[:min(limit, len(matches))] {
+		results = append(results, match.track)
+	}
+	return results
+}
+
+// levenshtein calcula la cantidad mínima de letras que hay que agregar, quitar o cambiar para pasar de a a b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
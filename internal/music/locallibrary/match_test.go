@@ -0,0 +1,54 @@
+package locallibrary
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	tracks := []*Track{
+		{Path: "soda/musica_ligera.mp3", Title: "De música ligera", Artist: "Soda Stereo"},
+		{Path: "soda/persiana.mp3", Title: "Persiana americana", Artist: "Soda Stereo"},
+		{Path: "cerati/crimen.mp3", Title: "Crimen", Artist: "Gustavo Cerati"},
+		{Path: "varios/ligera_en_vivo.mp3", Title: "De música ligera (en vivo en River)", Artist: "Soda Stereo"},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		paths []string
+	}{
+		{name: "Accents", query: "musica ligera", paths: []string{"soda/musica_ligera.mp3", "varios/ligera_en_vivo.mp3"}},
+		{name: "Artist", query: "cerati", paths: []string{"cerati/crimen.mp3"}},
+		{name: "Prefix", query: "pers", paths: []string{"soda/persiana.mp3"}},
+		{name: "Typo", query: "persianna americana", paths: []string{"soda/persiana.mp3"}},
+		{name: "AllWords", query: "soda crimen", paths: nil},
+		{name: "Empty", query: "  ", paths: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			for _, track := range search(tracks, tt.query, 5) {
+				paths = append(paths, track.Path)
+			}
+			assert.Equal(t, tt.paths, paths)
+		})
+	}
+}
+
+func TestSearch_Limit(t *testing.T) {
+	tracks := []*Track{
+		{Path: "a.mp3", Title: "Tema uno"},
+		{Path: "b.mp3", Title: "Tema dos"},
+	}
+
+	assert.Len(t, search(tracks, "tema", 1), 1)
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("flaca", "flaca"))
+	assert.Equal(t, 1, levenshtein("flca", "flaca"))
+	assert.Equal(t, 2, levenshtein("ñandú", "nandu"))
+	assert.Equal(t, 3, levenshtein("", "abc"))
+}
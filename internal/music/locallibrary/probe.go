@@ -0,0 +1,71 @@
+package locallibrary
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// probeOutput es la parte de la salida de ffprobe -show_format -show_streams que usa la biblioteca.
+type probeOutput struct {
+	Streams []struct {
+		CodecType   string            `json:"codec_type"`
+		Tags        map[string]string `json:"tags"`
+		Disposition struct {
+			AttachedPic int `json:"attached_pic"`
+		} `json:"disposition"`
+	} `json:"streams"`
+	Format struct {
+		Duration string            `json:"duration"`
+		Tags     map[string]string `json:"tags"`
+	} `json:"format"`
+}
+
+// parseProbe arma la pista del archivo relPath a partir de la salida JSON de ffprobe. Las etiquetas pueden estar
+// en el formato (MP3, M4A) o en la pista de audio (OGG, OPUS) y con cualquier combinación de mayúsculas.
+func parseProbe(relPath string, data []byte) (*Track, error) {
+	var probe probeOutput
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("error al leer la salida de ffprobe: %w", err)
+	}
+
+	tags := make(map[string]string)
+	addTags := func(t map[string]string) {
+		for key, value := range t {
+			if value = strings.TrimSpace(value); value != "" {
+				tags[strings.ToLower(key)] = value
+			}
+		}
+	}
+	addTags(probe.Format.Tags)
+
+	track := &Track{Path: relPath}
+	hasAudio := false
+	for _, stream := range probe.Streams {
+		switch {
+		case stream.CodecType == "audio":
+			hasAudio = true
+			addTags(stream.Tags)
+		case stream.CodecType == "video" && stream.Disposition.AttachedPic == 1:
+			track.HasCover = true
+		}
+	}
+	if !hasAudio {
+		return nil, ErrNoAudio
+	}
+
+	track.Title = tags["title"]
+	if track.Title == "" {
+		name := filepath.Base(relPath)
+		track.Title = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	track.Artist = tags["artist"]
+	track.Album = tags["album"]
+	if seconds, err := strconv.ParseFloat(probe.Format.Duration, 64); err == nil {
+		track.Duration = time.Duration(seconds * float64(time.Second))
+	}
+	return track, nil
+}
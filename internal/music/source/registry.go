@@ -0,0 +1,104 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"io"
+)
+
+// ErrUnknownSource indica que ninguna fuente registrada produce canciones del tipo pedido.
+var ErrUnknownSource = errors.New("fuente de canciones desconocida")
+
+type (
+	// Provider es una fuente de canciones: reconoce las entradas que puede resolver, obtiene la metadata
+	// de las canciones y produce sus datos de audio en formato DCA.
+	Provider interface {
+		// Name devuelve el tipo de las canciones que produce la fuente. Las canciones lo guardan en voice.Song.Type.
+		Name() string
+		// CanHandle indica si la fuente reconoce la entrada, por ejemplo por el patrón de la URL o por un prefijo de búsqueda.
+		CanHandle(input string) bool
+		// Resolve obtiene las canciones para la entrada. Las canciones devueltas pueden estar compartidas con una caché,
+		// por lo que no deben modificarse.
+		Resolve(ctx context.Context, input string) ([]*voice.Song, error)
+		// GetDCAData obtiene los datos de audio de una canción de la fuente en formato DCA.
+		GetDCAData(ctx context.Context, song *voice.Song) (io.Reader, error)
+	}
+
+	// Prefetcher lo implementan las fuentes que pueden precargar el audio de una canción antes de que suene.
+	Prefetcher interface {
+		Prefetch(ctx context.Context, song *voice.Song) error
+	}
+)
+
+// Registry elige la fuente de cada entrada y de cada canción entre las fuentes registradas.
+type Registry struct {
+	fallback  Provider
+	providers []Provider
+}
+
+// NewRegistry crea un registro cuya fuente por defecto es fallback. La fuente por defecto resuelve las entradas
+// que ninguna otra fuente reconoce, como los nombres de canciones a buscar.
+func NewRegistry(fallback Provider) *Registry {
+	return &Registry{
+		fallback:  fallback,
+		providers: []Provider{fallback},
+	}
+}
+
+// Register agrega una fuente. Las fuentes se consultan en el orden en que se registran, después de la fuente por defecto.
+func (r *Registry) Register(provider Provider) *Registry {
+	r.providers = append(r.providers, provider)
+	return r
+}
+
+// Resolve obtiene las canciones para la entrada con la primera fuente que la reconoce, o con la fuente por defecto.
+func (r *Registry) Resolve(ctx context.Context, input string) ([]*voice.Song, error) {
+	return r.providerFor(input).Resolve(ctx, input)
+}
+
+// GetDCAData obtiene los datos de audio de la canción con la fuente que la produjo. Las canciones sin tipo,
+// como las guardadas antes de que existieran otras fuentes, se reproducen con la fuente por defecto.
+func (r *Registry) GetDCAData(ctx context.Context, song *voice.Song) (io.Reader, error) {
+	provider, err := r.providerOf(song)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetDCAData(ctx, song)
+}
+
+// Prefetch precarga el audio de la canción si su fuente lo permite. Si no lo permite, no hace nada.
+func (r *Registry) Prefetch(ctx context.Context, song *voice.Song) error {
+	provider, err := r.providerOf(song)
+	if err != nil {
+		return err
+	}
+	if prefetcher, ok := provider.(Prefetcher); ok {
+		return prefetcher.Prefetch(ctx, song)
+	}
+	return nil
+}
+
+// providerFor devuelve la primera fuente que reconoce la entrada, o la fuente por defecto.
+func (r *Registry) providerFor(input string) Provider {
+	for _, provider := range r.providers {
+		if provider.CanHandle(input) {
+			return provider
+		}
+	}
+	return r.fallback
+}
+
+// providerOf devuelve la fuente que produjo la canción según su tipo.
+func (r *Registry) providerOf(song *voice.Song) (Provider, error) {
+	if song.Type == "" {
+		return r.fallback, nil
+	}
+	for _, provider := range r.providers {
+		if provider.Name() == song.Type {
+			return provider, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownSource, song.Type)
+}
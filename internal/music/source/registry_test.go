@@ -0,0 +1,84 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"github.com/Tomas-vilte/GoMusicBot/internal/discord/voice"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+// fakeProvider es una fuente que reconoce las entradas con su prefijo y devuelve su nombre como audio.
+type fakeProvider struct {
+	name       string
+	prefix     string
+	prefetched []*voice.Song
+}
+
+func (p *fakeProvider) Name() string { return p.name }
+
+func (p *fakeProvider) CanHandle(input string) bool {
+	return p.prefix != "" && strings.HasPrefix(input, p.prefix)
+}
+
+func (p *fakeProvider) Resolve(_ context.Context, input string) ([]*voice.Song, error) {
+	return []*voice.Song{{Type: p.name, Title: input}}, nil
+}
+
+func (p *fakeProvider) GetDCAData(_ context.Context, _ *voice.Song) (io.Reader, error) {
+	return strings.NewReader(p.name), nil
+}
+
+// prefetchingProvider es una fuente que además registra las canciones que precarga.
+type prefetchingProvider struct {
+	*fakeProvider
+}
+
+func (p prefetchingProvider) Prefetch(_ context.Context, song *voice.Song) error {
+	p.prefetched = append(p.prefetched, song)
+	return nil
+}
+
+func TestRegistry_Resolve(t *testing.T) {
+	youtube := &fakeProvider{name: "youtube", prefix: "https://youtube.com/"}
+	local := &fakeProvider{name: "local", prefix: "local:"}
+	registry := NewRegistry(youtube).Register(local)
+
+	songs, err := registry.Resolve(context.Background(), "local:tema")
+	assert.NoError(t, err)
+	assert.Equal(t, "local", songs[0].Type)
+
+	songs, err = registry.Resolve(context.Background(), "un tema cualquiera")
+	assert.NoError(t, err)
+	assert.Equal(t, "youtube", songs[0].Type)
+}
+
+func TestRegistry_GetDCAData(t *testing.T) {
+	youtube := &fakeProvider{name: "youtube"}
+	local := &fakeProvider{name: "local", prefix: "local:"}
+	registry := NewRegistry(youtube).Register(local)
+
+	read := func(song *voice.Song) string {
+		reader, err := registry.GetDCAData(context.Background(), song)
+		assert.NoError(t, err)
+		data, _ := io.ReadAll(reader)
+		return string(data)
+	}
+	assert.Equal(t, "local", read(&voice.Song{Type: "local"}))
+	assert.Equal(t, "youtube", read(&voice.Song{}))
+
+	_, err := registry.GetDCAData(context.Background(), &voice.Song{Type: "radio"})
+	assert.True(t, errors.Is(err, ErrUnknownSource))
+}
+
+func TestRegistry_Prefetch(t *testing.T) {
+	youtube := prefetchingProvider{&fakeProvider{name: "youtube"}}
+	local := &fakeProvider{name: "local", prefix: "local:"}
+	registry := NewRegistry(youtube).Register(local)
+
+	assert.NoError(t, registry.Prefetch(context.Background(), &voice.Song{Type: "youtube"}))
+	assert.NoError(t, registry.Prefetch(context.Background(), &voice.Song{Type: "local"}))
+	assert.Len(t, youtube.prefetched, 1)
+}
@@ -9,6 +9,10 @@ type (
 	// YouTubeService define una interfaz para las operaciones relacionadas con YouTube.
 	YouTubeService interface {
 		SearchVideoID(ctx context.Context, searchTerm string) (string, error)
+		SearchVideoIDs(ctx context.Context, searchTerm string, maxResults int64) ([]string, error)
 		GetVideoDetails(ctx context.Context, videoID string) (*youtube.Video, error)
+		SearchRelatedVideoIDs(ctx context.Context, videoID string, exclude []string, maxResults int64) ([]string, error)
+		GetPlaylistVideoIDs(ctx context.Context, playlistID string, maxResults int) ([]string, error)
+		GetVideosDetails(ctx context.Context, videoIDs []string) ([]*youtube.Video, error)
 	}
 )
@@ -2,17 +2,23 @@ package youtube_provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/Tomas-vilte/GoMusicBot/internal/logging"
 	"go.uber.org/zap"
 	"google.golang.org/api/youtube/v3"
+	"strings"
 )
 
+// maxResultsPerPage es la cantidad máxima de elementos que devuelve la API de YouTube por página o por pedido de detalles.
+const maxResultsPerPage = 50
+
 type (
 	// YouTubeClient define una interfaz para las operaciones con la API de YouTube.
 	YouTubeClient interface {
 		VideosListCall(ctx context.Context, part []string) VideosListCallWrapper
 		SearchListCall(ctx context.Context, part []string) SearchListCallWrapper
+		PlaylistItemsListCall(ctx context.Context, part []string) PlaylistItemsListCallWrapper
 	}
 
 	// YouTubeProvider implementa la interface providers.Service
@@ -51,6 +57,35 @@ func (p *YouTubeProvider) SearchVideoID(ctx context.Context, searchTerm string)
 	return videoID, nil
 }
 
+// SearchVideoIDs busca los IDs de los primeros maxResults videos de YouTube para un término de búsqueda,
+// en el orden en que los devuelve YouTube.
+func (p *YouTubeProvider) SearchVideoIDs(ctx context.Context, searchTerm string, maxResults int64) ([]string, error) {
+	p.logger.Info("Buscando videos en YouTube", zap.String("searchTerm", searchTerm), zap.Int64("maxResults", maxResults))
+	call := p.Client.SearchListCall(ctx, []string{"id"}).Q(searchTerm).MaxResults(maxResults).Type("video")
+
+	response, err := call.Do()
+	if err != nil {
+		p.logger.Error("Error al buscar videos en YouTube", zap.Error(err))
+		return nil, fmt.Errorf("error al buscar videos en YouTube: %w", err)
+	}
+
+	videoIDs := make([]string, 0, len(response.Items))
+	for _, item := range response.Items {
+		if item.Id == nil || item.Id.VideoId == "" {
+			continue
+		}
+		videoIDs = append(videoIDs, item.Id.VideoId)
+	}
+
+	if len(videoIDs) == 0 {
+		p.logger.Info("No se encontró ningún vídeo para el término de búsqueda", zap.String("searchTerm", searchTerm))
+		return nil, fmt.Errorf("no se encontró ningún vídeo para el término de búsqueda: %s", searchTerm)
+	}
+
+	p.logger.Info("Videos encontrados", zap.String("searchTerm", searchTerm), zap.Int("cantidad", len(videoIDs)))
+	return videoIDs, nil
+}
+
 // GetVideoDetails obtiene los detalles de un video de YouTube por su ID.
 func (p *YouTubeProvider) GetVideoDetails(ctx context.Context, videoID string) (*youtube.Video, error) {
 	p.logger.Info("Obteniendo detalles del video desde Youtube", zap.String("videoID", videoID))
@@ -72,3 +107,137 @@ func (p *YouTubeProvider) GetVideoDetails(ctx context.Context, videoID string) (
 
 	return response.Items[0], nil
 }
+
+// SearchRelatedVideoIDs busca hasta maxResults videos relacionados con el video indicado. Ni el video original
+// ni los videos de exclude forman parte del resultado.
+//
+// La API de YouTube ya no permite buscar por video relacionado, así que se usa una heurística: se buscan videos
+// de la misma categoría que el original (Música, por ejemplo) con el nombre del canal, o con el título si no lo
+// tiene. Así se priorizan otros temas del mismo artista sin salir del género. Como esa búsqueda suele devolver
+// temas recién escuchados, se piden tantos resultados extra como videos excluidos, hasta el máximo de la API.
+func (p *YouTubeProvider) SearchRelatedVideoIDs(ctx context.Context, videoID string, exclude []string, maxResults int64) ([]string, error) {
+	video, err := p.GetVideoDetails(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := video.Snippet.ChannelTitle
+	if query == "" {
+		query = video.Snippet.Title
+	}
+
+	excluded := make(map[string]struct{}, len(exclude)+1)
+	excluded[videoID] = struct{}{}
+	for _, id := range exclude {
+		excluded[id] = struct{}{}
+	}
+
+	p.logger.Info("Buscando videos relacionados en YouTube", zap.String("videoID", videoID), zap.String("query", query))
+	call := p.Client.SearchListCall(ctx, []string{"id"}).Q(query).MaxResults(min(maxResults+int64(len(excluded)), maxResultsPerPage)).Type("video")
+	if video.Snippet.CategoryId != "" {
+		call = call.VideoCategoryId(video.Snippet.CategoryId)
+	}
+
+	response, err := call.Do()
+	if err != nil {
+		p.logger.Error("Error al buscar videos relacionados en YouTube", zap.Error(err))
+		return nil, fmt.Errorf("error al buscar videos relacionados en YouTube: %w", err)
+	}
+
+	videoIDs := make([]string, 0, maxResults)
+	for _, item := range response.Items {
+		if int64(len(videoIDs)) == maxResults {
+			break
+		}
+		if item.Id == nil || item.Id.VideoId == "" {
+			continue
+		}
+		if _, ok := excluded[item.Id.VideoId]; ok {
+			continue
+		}
+		videoIDs = append(videoIDs, item.Id.VideoId)
+	}
+
+	if len(videoIDs) == 0 {
+		p.logger.Info("No se encontraron videos relacionados", zap.String("videoID", videoID))
+		return nil, fmt.Errorf("no se encontraron videos relacionados con el ID: %s", videoID)
+	}
+
+	p.logger.Info("Videos relacionados encontrados", zap.String("videoID", videoID), zap.Int("cantidad", len(videoIDs)))
+	return videoIDs, nil
+}
+
+// GetPlaylistVideoIDs obtiene los IDs de los primeros maxResults videos de una lista de reproducción de YouTube,
+// en el orden de la lista. Recorre las páginas de la lista hasta juntar maxResults videos o llegar al final.
+func (p *YouTubeProvider) GetPlaylistVideoIDs(ctx context.Context, playlistID string, maxResults int) ([]string, error) {
+	p.logger.Info("Obteniendo videos de la lista de reproducción desde YouTube", zap.String("playlistID", playlistID), zap.Int("maxResults", maxResults))
+
+	videoIDs := make([]string, 0, maxResults)
+	pageToken := ""
+	for len(videoIDs) < maxResults {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		call := p.Client.PlaylistItemsListCall(ctx, []string{"contentDetails"}).
+			PlaylistId(playlistID).
+			MaxResults(int64(min(maxResults-len(videoIDs), maxResultsPerPage)))
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		response, err := call.Do()
+		if err != nil {
+			p.logger.Error("Error al obtener la lista de reproducción desde YouTube", zap.Error(err))
+			return nil, fmt.Errorf("error al obtener la lista de reproducción: %w", err)
+		}
+
+		for _, item := range response.Items {
+			if item.ContentDetails == nil || item.ContentDetails.VideoId == "" {
+				continue
+			}
+			videoIDs = append(videoIDs, item.ContentDetails.VideoId)
+		}
+
+		pageToken = response.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	if len(videoIDs) == 0 {
+		p.logger.Info("Lista de reproducción vacía o inexistente", zap.String("playlistID", playlistID))
+		return nil, fmt.Errorf("no se encontraron videos en la lista de reproducción: %s", playlistID)
+	}
+
+	videoIDs = videoIDs[:min(len(videoIDs), maxResults)]
+	p.logger.Info("Videos de la lista de reproducción obtenidos", zap.String("playlistID", playlistID), zap.Int("cantidad", len(videoIDs)))
+	return videoIDs, nil
+}
+
+// GetVideosDetails obtiene los detalles de varios videos de YouTube pidiéndolos de a maxResultsPerPage.
+// Los videos que no existen o son privados no forman parte del resultado.
+func (p *YouTubeProvider) GetVideosDetails(ctx context.Context, videoIDs []string) ([]*youtube.Video, error) {
+	if len(videoIDs) == 0 {
+		return nil, errors.New("no se indicó ningún video")
+	}
+
+	videos := make([]*youtube.Video, 0, len(videoIDs))
+	for start := 0; start < len(videoIDs); start += maxResultsPerPage {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		batch := videoIDs[start:min(start+maxResultsPerPage, len(videoIDs))]
+		call := p.Client.VideosListCall(ctx, []string{"snippet", "contentDetails", "liveStreamingDetails"}).Id(strings.Join(batch, ","))
+		response, err := call.Do()
+		if err != nil {
+			p.logger.Error("Error al obtener detalles de los videos desde Youtube", zap.Error(err))
+			return nil, fmt.Errorf("error al obtener detalles de los videos: %w", err)
+		}
+		videos = append(videos, response.Items...)
+	}
+
+	p.logger.Info("Detalles de los videos recuperados exitosamente", zap.Int("pedidos", len(videoIDs)), zap.Int("encontrados", len(videos)))
+	return videos, nil
+}
@@ -13,6 +13,7 @@ type (
 		Q(q string) SearchListCallWrapper
 		MaxResults(maxResults int64) SearchListCallWrapper
 		Type(typ string) SearchListCallWrapper
+		VideoCategoryId(categoryID string) SearchListCallWrapper
 		Do() (*youtube.SearchListResponse, error)
 	}
 
@@ -22,6 +23,14 @@ type (
 		Do() (*youtube.VideoListResponse, error)
 	}
 
+	// PlaylistItemsListCallWrapper es una interfaz que envuelve youtube.PlaylistItemsListCall
+	PlaylistItemsListCallWrapper interface {
+		PlaylistId(playlistID string) PlaylistItemsListCallWrapper
+		MaxResults(maxResults int64) PlaylistItemsListCallWrapper
+		PageToken(pageToken string) PlaylistItemsListCallWrapper
+		Do() (*youtube.PlaylistItemListResponse, error)
+	}
+
 	RealVideosListCallWrapper struct {
 		Call *youtube.VideosListCall
 	}
@@ -30,6 +39,10 @@ type (
 		Call *youtube.SearchListCall
 	}
 
+	RealPlaylistItemsListCallWrapper struct {
+		Call *youtube.PlaylistItemsListCall
+	}
+
 	RealYouTubeClient struct {
 		Service *youtube.Service
 	}
@@ -52,6 +65,10 @@ func (c *RealYouTubeClient) SearchListCall(ctx context.Context, part []string) S
 	return &RealSearchListCallWrapper{Call: c.Service.Search.List(part)}
 }
 
+func (c *RealYouTubeClient) PlaylistItemsListCall(ctx context.Context, part []string) PlaylistItemsListCallWrapper {
+	return &RealPlaylistItemsListCallWrapper{Call: c.Service.PlaylistItems.List(part)}
+}
+
 func (r *RealSearchListCallWrapper) Q(q string) SearchListCallWrapper {
 	r.Call.Q(q)
 	return r
@@ -67,6 +84,11 @@ func (r *RealSearchListCallWrapper) Type(typ string) SearchListCallWrapper {
 	return r
 }
 
+func (r *RealSearchListCallWrapper) VideoCategoryId(categoryID string) SearchListCallWrapper {
+	r.Call.VideoCategoryId(categoryID)
+	return r
+}
+
 func (r *RealSearchListCallWrapper) Do() (*youtube.SearchListResponse, error) {
 	return r.Call.Do()
 }
@@ -79,3 +101,22 @@ func (r *RealVideosListCallWrapper) Id(id string) VideosListCallWrapper {
 func (r *RealVideosListCallWrapper) Do() (*youtube.VideoListResponse, error) {
 	return r.Call.Do()
 }
+
+func (r *RealPlaylistItemsListCallWrapper) PlaylistId(playlistID string) PlaylistItemsListCallWrapper {
+	r.Call.PlaylistId(playlistID)
+	return r
+}
+
+func (r *RealPlaylistItemsListCallWrapper) MaxResults(maxResults int64) PlaylistItemsListCallWrapper {
+	r.Call.MaxResults(maxResults)
+	return r
+}
+
+func (r *RealPlaylistItemsListCallWrapper) PageToken(pageToken string) PlaylistItemsListCallWrapper {
+	r.Call.PageToken(pageToken)
+	return r
+}
+
+func (r *RealPlaylistItemsListCallWrapper) Do() (*youtube.PlaylistItemListResponse, error) {
+	return r.Call.Do()
+}
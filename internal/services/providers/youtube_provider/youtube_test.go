@@ -176,3 +176,199 @@ func TestGetVideoDetails(t *testing.T) {
 		loggerMock.AssertExpectations(t)
 	})
 }
+
+func TestSearchRelatedVideoIDs(t *testing.T) {
+	t.Run("successful search excludes original and recent videos", func(t *testing.T) {
+		clientMock := new(MockYouTubeClient)
+		loggerMock := new(logging.MockLogger)
+		videosCallMock := new(VideosListCallWrapperMock)
+		searchCallMock := new(SearchListCallWrapperMock)
+
+		clientMock.On("VideosListCall", mock.Anything, []string{"snippet", "contentDetails", "liveStreamingDetails"}).Return(videosCallMock)
+		videosCallMock.On("Id", "12345").Return(videosCallMock)
+		videosCallMock.On("Do").Return(&youtube.VideoListResponse{
+			Items: []*youtube.Video{{Snippet: &youtube.VideoSnippet{Title: "Test Video", ChannelTitle: "Test Channel", CategoryId: "10"}}},
+		}, nil)
+
+		clientMock.On("SearchListCall", mock.Anything, []string{"id"}).Return(searchCallMock)
+		searchCallMock.On("Q", "Test Channel").Return(searchCallMock)
+		// Se piden resultados extra para compensar el video original y el excluido.
+		searchCallMock.On("MaxResults", int64(4)).Return(searchCallMock)
+		searchCallMock.On("Type", "video").Return(searchCallMock)
+		searchCallMock.On("VideoCategoryId", "10").Return(searchCallMock)
+		searchCallMock.On("Do").Return(&youtube.SearchListResponse{
+			Items: []*youtube.SearchResult{
+				{Id: &youtube.ResourceId{VideoId: "12345"}},
+				{Id: &youtube.ResourceId{VideoId: "67890"}},
+				{Id: &youtube.ResourceId{VideoId: "abcde"}},
+				{Id: &youtube.ResourceId{VideoId: "fghij"}},
+			},
+		}, nil)
+
+		loggerMock.On("Info", mock.Anything, mock.Anything).Return()
+
+		provider := NewYouTubeProvider("dummyApiKey", loggerMock, clientMock)
+		videoIDs, err := provider.SearchRelatedVideoIDs(context.Background(), "12345", []string{"67890"}, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"abcde", "fghij"}, videoIDs)
+
+		clientMock.AssertExpectations(t)
+		videosCallMock.AssertExpectations(t)
+		searchCallMock.AssertExpectations(t)
+	})
+
+	t.Run("no related videos found", func(t *testing.T) {
+		clientMock := new(MockYouTubeClient)
+		loggerMock := new(logging.MockLogger)
+		videosCallMock := new(VideosListCallWrapperMock)
+		searchCallMock := new(SearchListCallWrapperMock)
+
+		clientMock.On("VideosListCall", mock.Anything, []string{"snippet", "contentDetails", "liveStreamingDetails"}).Return(videosCallMock)
+		videosCallMock.On("Id", "12345").Return(videosCallMock)
+		videosCallMock.On("Do").Return(&youtube.VideoListResponse{
+			Items: []*youtube.Video{{Snippet: &youtube.VideoSnippet{Title: "Test Video"}}},
+		}, nil)
+
+		clientMock.On("SearchListCall", mock.Anything, []string{"id"}).Return(searchCallMock)
+		searchCallMock.On("Q", "Test Video").Return(searchCallMock)
+		searchCallMock.On("MaxResults", int64(6)).Return(searchCallMock)
+		searchCallMock.On("Type", "video").Return(searchCallMock)
+		searchCallMock.On("Do").Return(&youtube.SearchListResponse{
+			Items: []*youtube.SearchResult{{Id: &youtube.ResourceId{VideoId: "12345"}}},
+		}, nil)
+
+		loggerMock.On("Info", mock.Anything, mock.Anything).Return()
+
+		provider := NewYouTubeProvider("dummyApiKey", loggerMock, clientMock)
+		videoIDs, err := provider.SearchRelatedVideoIDs(context.Background(), "12345", nil, 5)
+		assert.Error(t, err)
+		assert.Nil(t, videoIDs)
+		assert.Contains(t, err.Error(), "no se encontraron videos relacionados")
+	})
+}
+
+func TestSearchVideoIDs(t *testing.T) {
+	t.Run("successful search keeps order", func(t *testing.T) {
+		clientMock := new(MockYouTubeClient)
+		loggerMock := new(logging.MockLogger)
+		searchCallMock := new(SearchListCallWrapperMock)
+
+		clientMock.On("SearchListCall", mock.Anything, []string{"id"}).Return(searchCallMock)
+		searchCallMock.On("Q", "test").Return(searchCallMock)
+		searchCallMock.On("MaxResults", int64(3)).Return(searchCallMock)
+		searchCallMock.On("Type", "video").Return(searchCallMock)
+		searchCallMock.On("Do").Return(&youtube.SearchListResponse{
+			Items: []*youtube.SearchResult{
+				{Id: &youtube.ResourceId{VideoId: "12345"}},
+				{Id: &youtube.ResourceId{}},
+				{Id: &youtube.ResourceId{VideoId: "67890"}},
+			},
+		}, nil)
+		loggerMock.On("Info", mock.Anything, mock.Anything).Return()
+
+		provider := NewYouTubeProvider("dummyApiKey", loggerMock, clientMock)
+		videoIDs, err := provider.SearchVideoIDs(context.Background(), "test", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"12345", "67890"}, videoIDs)
+
+		clientMock.AssertExpectations(t)
+		searchCallMock.AssertExpectations(t)
+	})
+
+	t.Run("no video found", func(t *testing.T) {
+		clientMock := new(MockYouTubeClient)
+		loggerMock := new(logging.MockLogger)
+		searchCallMock := new(SearchListCallWrapperMock)
+
+		clientMock.On("SearchListCall", mock.Anything, []string{"id"}).Return(searchCallMock)
+		searchCallMock.On("Q", "test").Return(searchCallMock)
+		searchCallMock.On("MaxResults", int64(3)).Return(searchCallMock)
+		searchCallMock.On("Type", "video").Return(searchCallMock)
+		searchCallMock.On("Do").Return(&youtube.SearchListResponse{}, nil)
+		loggerMock.On("Info", mock.Anything, mock.Anything).Return()
+
+		provider := NewYouTubeProvider("dummyApiKey", loggerMock, clientMock)
+		videoIDs, err := provider.SearchVideoIDs(context.Background(), "test", 3)
+		assert.Error(t, err)
+		assert.Nil(t, videoIDs)
+		assert.Contains(t, err.Error(), "no se encontró ningún vídeo para el término de búsqueda")
+	})
+}
+
+func TestGetPlaylistVideoIDs(t *testing.T) {
+	t.Run("follows pages until max results", func(t *testing.T) {
+		clientMock := new(MockYouTubeClient)
+		loggerMock := new(logging.MockLogger)
+		firstPage := new(PlaylistItemsListCallWrapperMock)
+		secondPage := new(PlaylistItemsListCallWrapperMock)
+
+		clientMock.On("PlaylistItemsListCall", mock.Anything, []string{"contentDetails"}).Return(firstPage).Once()
+		firstPage.On("PlaylistId", "PL123").Return(firstPage)
+		firstPage.On("MaxResults", int64(3)).Return(firstPage)
+		firstPage.On("Do").Return(&youtube.PlaylistItemListResponse{
+			Items: []*youtube.PlaylistItem{
+				{ContentDetails: &youtube.PlaylistItemContentDetails{VideoId: "a"}},
+				{ContentDetails: &youtube.PlaylistItemContentDetails{VideoId: "b"}},
+			},
+			NextPageToken: "page2",
+		}, nil)
+
+		clientMock.On("PlaylistItemsListCall", mock.Anything, []string{"contentDetails"}).Return(secondPage).Once()
+		secondPage.On("PlaylistId", "PL123").Return(secondPage)
+		secondPage.On("MaxResults", int64(1)).Return(secondPage)
+		secondPage.On("PageToken", "page2").Return(secondPage)
+		secondPage.On("Do").Return(&youtube.PlaylistItemListResponse{
+			Items: []*youtube.PlaylistItem{
+				{ContentDetails: &youtube.PlaylistItemContentDetails{VideoId: "c"}},
+			},
+			NextPageToken: "page3",
+		}, nil)
+		loggerMock.On("Info", mock.Anything, mock.Anything).Return()
+
+		provider := NewYouTubeProvider("dummyApiKey", loggerMock, clientMock)
+		videoIDs, err := provider.GetPlaylistVideoIDs(context.Background(), "PL123", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, videoIDs)
+
+		clientMock.AssertExpectations(t)
+		firstPage.AssertExpectations(t)
+		secondPage.AssertExpectations(t)
+	})
+
+	t.Run("empty playlist", func(t *testing.T) {
+		clientMock := new(MockYouTubeClient)
+		loggerMock := new(logging.MockLogger)
+		callMock := new(PlaylistItemsListCallWrapperMock)
+
+		clientMock.On("PlaylistItemsListCall", mock.Anything, []string{"contentDetails"}).Return(callMock)
+		callMock.On("PlaylistId", "PL123").Return(callMock)
+		callMock.On("MaxResults", int64(10)).Return(callMock)
+		callMock.On("Do").Return(&youtube.PlaylistItemListResponse{}, nil)
+		loggerMock.On("Info", mock.Anything, mock.Anything).Return()
+
+		provider := NewYouTubeProvider("dummyApiKey", loggerMock, clientMock)
+		videoIDs, err := provider.GetPlaylistVideoIDs(context.Background(), "PL123", 10)
+		assert.Error(t, err)
+		assert.Nil(t, videoIDs)
+		assert.Contains(t, err.Error(), "no se encontraron videos en la lista de reproducción")
+	})
+}
+
+func TestGetVideosDetails(t *testing.T) {
+	clientMock := new(MockYouTubeClient)
+	loggerMock := new(logging.MockLogger)
+	videosCallMock := new(VideosListCallWrapperMock)
+
+	clientMock.On("VideosListCall", mock.Anything, []string{"snippet", "contentDetails", "liveStreamingDetails"}).Return(videosCallMock)
+	videosCallMock.On("Id", "a,b").Return(videosCallMock)
+	videosCallMock.On("Do").Return(&youtube.VideoListResponse{
+		Items: []*youtube.Video{{Id: "a"}},
+	}, nil)
+	loggerMock.On("Info", mock.Anything, mock.Anything).Return()
+
+	provider := NewYouTubeProvider("dummyApiKey", loggerMock, clientMock)
+	videos, err := provider.GetVideosDetails(context.Background(), []string{"a", "b"})
+	assert.NoError(t, err)
+	assert.Len(t, videos, 1)
+	assert.Equal(t, "a", videos[0].Id)
+}
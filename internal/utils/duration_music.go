@@ -2,6 +2,8 @@ package utils
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -21,6 +23,29 @@ func FmtDuration(d time.Duration) string {
 	return fmt.Sprintf("%02d:%02d", m, s)
 }
 
+// ParseTimestamp convierte una marca de tiempo con formato "ss", "mm:ss" o "hh:mm:ss" en una duración.
+func ParseTimestamp(timestamp string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(timestamp), ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("formato de marca de tiempo no válido: %s", timestamp)
+	}
+
+	var duration time.Duration
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil || value < 0 {
+			return 0, fmt.Errorf("formato de marca de tiempo no válido: %s", timestamp)
+		}
+		// Los minutos y segundos no pueden superar 59 salvo en el primer componente.
+		if i > 0 && value > 59 {
+			return 0, fmt.Errorf("formato de marca de tiempo no válido: %s", timestamp)
+		}
+		duration = duration*60 + time.Duration(value)
+	}
+
+	return duration * time.Second, nil
+}
+
 func String(s string) *string {
 	return &s
 }
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"45", 45 * time.Second},
+		{"1:23", time.Minute + 23*time.Second},
+		{"90:00", 90 * time.Minute},
+		{"1:02:03", time.Hour + 2*time.Minute + 3*time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			duration, err := ParseTimestamp(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, duration)
+		})
+	}
+}
+
+func TestParseTimestamp_Invalid(t *testing.T) {
+	for _, input := range []string{"", "abc", "1:75", "-5", "1:2:3:4"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseTimestamp(input)
+			assert.Error(t, err)
+		})
+	}
+}